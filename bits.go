@@ -1,5 +1,7 @@
 package gomisc
 
+import "math/bits"
+
 // lowest `n` bits from `value`.
 func LowestBitsU16(value u16, n u8) u16 {
 	return value & u16(1<<n-1)
@@ -24,3 +26,29 @@ func RotateU32(value u32, n u8) u32 {
 func RotateU64(value u64, n u8) u64 {
 	return value<<n | value>>(64-n)
 }
+
+// Unsigned 128bit integer.
+type u128 struct {
+	hi, lo u64
+}
+
+func (a u128) add(b u128) u128 {
+	lo, carry := bits.Add64(a.lo, b.lo, 0)
+	hi, _ := bits.Add64(a.hi, b.hi, carry)
+	return u128{hi, lo}
+}
+
+func (a u128) mul(b u128) u128 {
+	hi, lo := bits.Mul64(a.lo, b.lo)
+	return u128{hi + a.hi*b.lo + a.lo*b.hi, lo}
+}
+
+func (a u128) sub(b u128) u128 {
+	lo, borrow := bits.Sub64(a.lo, b.lo, 0)
+	hi, _ := bits.Sub64(a.hi, b.hi, borrow)
+	return u128{hi, lo}
+}
+
+func (a u128) shr1() u128 {
+	return u128{a.hi >> 1, a.hi<<63 | a.lo>>1}
+}
@@ -0,0 +1,22 @@
+// Reports statistical quality p-values of the gomisc generators and hashes.
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/IgneousRed/gomisc"
+)
+
+func main() {
+	seed := flag.Uint64("seed", 1, "seed of every generator")
+	flag.Parse()
+
+	for _, r := range gomisc.QualityReport(*seed) {
+		verdict := ""
+		if r.P < 1e-4 || r.P > 1-1e-4 {
+			verdict = "FAIL"
+		}
+		fmt.Printf("%-14s %-22s %.6f %v\n", r.Subject, r.Name, r.P, verdict)
+	}
+}
@@ -0,0 +1,190 @@
+package gomisc
+
+// Overlap of 2 shapes.
+type Contact struct {
+	Point  Vector2 // Within the overlap
+	Normal Vector2 // Unit direction from the first shape towards the second
+	Depth  f64     // Distance to move the second shape along Normal to separate
+}
+
+// Do `c` and `other` share any point.
+func (c Circle) Overlaps(other Circle) bool {
+	r := c.Radius + other.Radius
+	return c.Center.Sub(other.Center).MagSq() <= r*r
+}
+
+// Crossing points of `l` and the outline of `c`, sorted along `l`.
+func (c Circle) IntersectLine(l Line) []Vector2 {
+	closest := l.ClosestPoint(c.Center)
+	dstSq := closest.Sub(c.Center).MagSq()
+	if dstSq > c.Radius*c.Radius {
+		return nil
+	}
+	half := Sqrt(c.Radius*c.Radius - dstSq)
+	if half == 0 {
+		return []Vector2{closest}
+	}
+	return []Vector2{closest.Sub(l.Dir.Mul1(half)), closest.Add(l.Dir.Mul1(half))}
+}
+
+// Crossing points of `s` and the outline of `c`, sorted from `s.A`.
+func (s Segment) IntersectCircle(c Circle) []Vector2 {
+	if s.A.Eq(s.B) {
+		return nil
+	}
+	var result []Vector2
+	for _, p := range c.IntersectLine(LineNew(s.A, s.B)) {
+		if _, t := s.closest(p); t > 0 && t < 1 || p.Eq(s.A) || p.Eq(s.B) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Overlap of circles `a` and `b`.
+func CollideCircles(a, b Circle) (Contact, bool) {
+	offset := b.Center.Sub(a.Center)
+	dst := offset.Mag()
+	depth := a.Radius + b.Radius - dst
+	if depth < 0 {
+		return Contact{}, false
+	}
+	normal := Vec2(1, 0)
+	if dst != 0 {
+		normal = offset.Div1(dst)
+	}
+	return Contact{normal.Mul1(a.Radius - depth/2).Add(a.Center), normal, depth}, true
+}
+
+// Overlap of circle `c` and rect `r`.
+func CollideCircleRect(c Circle, r Rect) (Contact, bool) {
+	closest := r.ClosestPoint(c.Center)
+	if !closest.Eq(c.Center) {
+		offset := closest.Sub(c.Center)
+		dst := offset.Mag()
+		if dst > c.Radius {
+			return Contact{}, false
+		}
+		return Contact{closest, offset.Div1(dst), c.Radius - dst}, true
+	}
+	// Center inside, push out through the nearest side
+	sides := [4]f64{
+		c.Center[0] - r.Min[0], r.Max[0] - c.Center[0],
+		c.Center[1] - r.Min[1], r.Max[1] - c.Center[1],
+	}
+	normals := [4]Vector2{Vec2(1, 0), Vec2(-1, 0), Vec2(0, 1), Vec2(0, -1)}
+	nearest := 0
+	for i, d := range sides {
+		if d < sides[nearest] {
+			nearest = i
+		}
+	}
+	return Contact{c.Center, normals[nearest], sides[nearest] + c.Radius}, true
+}
+
+// Overlap of rects `a` and `b`.
+func CollideRects(a, b Rect) (Contact, bool) {
+	shared, ok := a.Intersection(b)
+	if !ok {
+		return Contact{}, false
+	}
+	normal, depth := Vector2{}, Inf
+	for axis := 0; axis < 2; axis++ {
+		var unit Vector2
+		unit[axis] = 1
+		if d, n := separation(a.Min[axis], a.Max[axis], b.Min[axis], b.Max[axis], unit); d < depth {
+			normal, depth = n, d
+		}
+	}
+	return Contact{shared.Center(), normal, depth}, true
+}
+
+// Shortest move of [`minB`,`maxB`] along `axis` either way to clear [`minA`,`maxA`],
+// and the direction of that move. Negative if they don't overlap.
+func separation(minA, maxA, minB, maxB f64, axis Vector2) (f64, Vector2) {
+	forward, backward := maxA-minB, maxB-minA
+	if backward < forward {
+		return backward, axis.Neg()
+	}
+	return forward, axis
+}
+
+// Overlap of circle `c` and convex polygon `p` (separating axis theorem).
+func CollideCirclePolygon(c Circle, p Polygon) (Contact, bool) {
+	closest := p[0]
+	for _, v := range p[1:] {
+		if v.Dst(c.Center) < closest.Dst(c.Center) {
+			closest = v
+		}
+	}
+	axes := append(polygonAxes(p), closest.Sub(c.Center).Norm())
+	normal, depth := Vector2{}, Inf
+	for _, axis := range axes {
+		if axis.Eq(Vector2{}) {
+			continue
+		}
+		center := c.Center.Dot(axis)
+		min, max := projectPolygon(p, axis)
+		overlap, n := separation(center-c.Radius, center+c.Radius, min, max, axis)
+		if overlap < 0 {
+			return Contact{}, false
+		} else if overlap < depth {
+			normal, depth = n, overlap
+		}
+	}
+	return Contact{normal.Mul1(c.Radius - depth/2).Add(c.Center), normal, depth}, true
+}
+
+// Overlap of convex polygons `a` and `b` (separating axis theorem).
+// Rect, OBB and Triangle convert with their Polygon method.
+func CollidePolygons(a, b Polygon) (Contact, bool) {
+	normal, depth := Vector2{}, Inf
+	for _, axis := range append(polygonAxes(a), polygonAxes(b)...) {
+		minA, maxA := projectPolygon(a, axis)
+		minB, maxB := projectPolygon(b, axis)
+		overlap, n := separation(minA, maxA, minB, maxB, axis)
+		if overlap < 0 {
+			return Contact{}, false
+		} else if overlap < depth {
+			normal, depth = n, overlap
+		}
+	}
+	// Vertex of `b` reaching deepest into `a`
+	point := b[0]
+	for _, v := range b[1:] {
+		if v.Dot(normal) < point.Dot(normal) {
+			point = v
+		}
+	}
+	return Contact{point, normal, depth}, true
+}
+
+// Unit normals of `p` edges, skipping degenerate edges.
+func polygonAxes(p Polygon) []Vector2 {
+	result := make([]Vector2, 0, len(p))
+	for _, edge := range p.Edges() {
+		if !edge.A.Eq(edge.B) {
+			result = append(result, edge.Normal())
+		}
+	}
+	return result
+}
+
+// Lowest and highest dot product of `p` vertices with `axis`.
+func projectPolygon(p Polygon, axis Vector2) (min, max f64) {
+	min, max = Inf, -Inf
+	for _, v := range p {
+		d := v.Dot(axis)
+		min, max = Min(min, d), Max(max, d)
+	}
+	return min, max
+}
+
+// Average of `p` vertices.
+func vertexMean(p Polygon) Vector2 {
+	result := Vector2{}
+	for _, v := range p {
+		result = result.Add(v)
+	}
+	return result.Div1(f64(len(p)))
+}
@@ -0,0 +1,65 @@
+package gomisc
+
+import "testing"
+
+// Fails unless `got` has `normal` and `depth`.
+func testContact(t *testing.T, name string, got Contact, ok bool, normal Vector2, depth f64) {
+	t.Helper()
+	if !ok {
+		t.Errorf("%s: no contact", name)
+	} else if got.Normal.Sub(normal).Mag() > 1e-9 || Abs(got.Depth-depth) > 1e-9 {
+		t.Errorf("%s: normal %v depth %v, want %v %v", name, got.Normal, got.Depth, normal, depth)
+	}
+}
+
+func TestCollideContained(t *testing.T) {
+	square := Rect{Max: Vec2(10, 10)}
+	circle := Circle{Vec2(1.5, 5), 1}
+	contact, ok := CollideCircleRect(circle, square)
+	testContact(t, "CollideCircleRect", contact, ok, Vec2(1, 0), 2.5)
+	contact, ok = CollideCirclePolygon(circle, square.Polygon())
+	testContact(t, "CollideCirclePolygon", contact, ok, Vec2(1, 0), 2.5)
+
+	inner := Rect{Vec2(4, 4), Vec2(5, 6)}
+	contact, ok = CollideRects(square, inner)
+	testContact(t, "CollideRects", contact, ok, Vec2(-1, 0), 5)
+	contact, ok = CollidePolygons(square.Polygon(), inner.Polygon())
+	testContact(t, "CollidePolygons", contact, ok, Vec2(-1, 0), 5)
+	contact, ok = CollideRects(inner, square)
+	testContact(t, "CollideRects swapped", contact, ok, Vec2(1, 0), 5)
+}
+
+// Moving the second shape by Depth along Normal only just separates it.
+func TestCollideSeparates(t *testing.T) {
+	s := PCG32New(1)
+	rect := func() Rect {
+		return RectNew(Vec2(s.Float64(), s.Float64()).Mul1(10), Vec2(s.Float64(), s.Float64()).Mul1(10))
+	}
+	for i := 0; i < 1000; i++ {
+		a, b := rect(), rect()
+		contact, ok := CollideRects(a, b)
+		if !ok {
+			continue
+		}
+		moved := b.Polygon()
+		for j := range moved {
+			moved[j] = moved[j].Add(contact.Normal.Mul1(contact.Depth + 1e-9))
+		}
+		if _, ok := CollidePolygons(a.Polygon(), moved); ok {
+			t.Fatalf("%v and %v still overlap after %v", a, b, contact)
+		}
+		polygon, _ := CollidePolygons(a.Polygon(), b.Polygon())
+		testContact(t, "CollidePolygons", polygon, true, contact.Normal, contact.Depth)
+
+		c := Circle{Vec2(s.Float64(), s.Float64()).Mul1(10), s.Float64() * 3}
+		if contact, ok = CollideCirclePolygon(c, b.Polygon()); !ok {
+			continue
+		}
+		for j := range moved {
+			moved[j] = b.Polygon()[j].Add(contact.Normal.Mul1(contact.Depth + 1e-9))
+		}
+		if _, ok := CollideCirclePolygon(c, moved); ok {
+			t.Fatalf("%v and %v still overlap after %v", c, b, contact)
+		}
+	}
+}
@@ -0,0 +1,570 @@
+package gomisc
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Source safe for concurrent use, guarded by a mutex.
+type Locked struct {
+	mu  sync.Mutex
+	src Source
+}
+
+// Guards `s`, which must not be used directly afterwards.
+func LockedNew(s Source) *Locked {
+	return &Locked{src: s}
+}
+
+// Generates a random uint32 number
+func (s *Locked) Next() u32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Next()
+}
+
+// Generates a random uint64 number
+func (s *Locked) Next64() u64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Next64()
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (s *Locked) Range(n int) int {
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *Locked) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *Locked) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *Locked) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *Locked) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *Locked) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *Locked) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
+}
+
+// Generates number in range [0,1]
+func (s *Locked) Normal32() f32 {
+	return RandNormal32(s)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (s *Locked) Normal64() f64 {
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *Locked) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *Locked) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *Locked) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *Locked) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *Locked) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *Locked) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
+
+// MCG32 safe for concurrent use without locks.
+type AtomicMCG32 struct {
+	state atomic.Uint64
+}
+
+// Initializes with seed
+func AtomicMCG32New(seed u64) *AtomicMCG32 {
+	s := &AtomicMCG32{}
+	s.state.Store(u64(MCG32New(seed)))
+	return s
+}
+
+// Initializes with SeedGen64
+func AtomicMCG32Init() *AtomicMCG32 {
+	return AtomicMCG32New(SeedGen64())
+}
+
+// Generates a random uint32 number
+func (s *AtomicMCG32) Next() u32 {
+	for {
+		state := s.state.Load()
+		if s.state.CompareAndSwap(state, state*0xf13283ad) {
+			return u32(state >> 32)
+		}
+	}
+}
+
+// Generates a random uint64 number
+func (s *AtomicMCG32) Next64() u64 {
+	return u64(s.Next())<<32 | u64(s.Next())
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (s *AtomicMCG32) Range(n int) int {
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *AtomicMCG32) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *AtomicMCG32) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *AtomicMCG32) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *AtomicMCG32) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *AtomicMCG32) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *AtomicMCG32) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
+}
+
+// Generates number in range [0,1]
+func (s *AtomicMCG32) Normal32() f32 {
+	return RandNormal32(s)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (s *AtomicMCG32) Normal64() f64 {
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *AtomicMCG32) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *AtomicMCG32) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *AtomicMCG32) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *AtomicMCG32) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *AtomicMCG32) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *AtomicMCG32) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
+
+// PCG32Fast safe for concurrent use without locks.
+type AtomicPCG32Fast struct {
+	state atomic.Uint64
+}
+
+// Initializes with seed
+func AtomicPCG32FastNew(seed u64) *AtomicPCG32Fast {
+	s := &AtomicPCG32Fast{}
+	s.state.Store(u64(PCG32FastNew(seed)))
+	return s
+}
+
+// Initializes with SeedGen64
+func AtomicPCG32FastInit() *AtomicPCG32Fast {
+	return AtomicPCG32FastNew(SeedGen64())
+}
+
+// Generates a random uint32 number
+func (s *AtomicPCG32Fast) Next() u32 {
+	for {
+		state := s.state.Load()
+		if s.state.CompareAndSwap(state, state*0xf13283ad) {
+			return u32((state ^ state>>22) >> (22 + state>>61))
+		}
+	}
+}
+
+// Generates a random uint64 number
+func (s *AtomicPCG32Fast) Next64() u64 {
+	return u64(s.Next())<<32 | u64(s.Next())
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (s *AtomicPCG32Fast) Range(n int) int {
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *AtomicPCG32Fast) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *AtomicPCG32Fast) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *AtomicPCG32Fast) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *AtomicPCG32Fast) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *AtomicPCG32Fast) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *AtomicPCG32Fast) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
+}
+
+// Generates number in range [0,1]
+func (s *AtomicPCG32Fast) Normal32() f32 {
+	return RandNormal32(s)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (s *AtomicPCG32Fast) Normal64() f64 {
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *AtomicPCG32Fast) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *AtomicPCG32Fast) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *AtomicPCG32Fast) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *AtomicPCG32Fast) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *AtomicPCG32Fast) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *AtomicPCG32Fast) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
+
+// PCG32 safe for concurrent use without locks.
+type AtomicPCG32 struct {
+	state atomic.Uint64
+	inc   u64
+}
+
+// Initializes with seed
+func AtomicPCG32New(seed u64) *AtomicPCG32 {
+	return AtomicPCG32NewStream(seed, pcg32Inc>>1)
+}
+
+// Initializes with seed on a selected stream.
+// Different streams produce independent sequences.
+func AtomicPCG32NewStream(seed, stream u64) *AtomicPCG32 {
+	s := &AtomicPCG32{inc: stream<<1 | 1}
+	s.state.Store(seed)
+	return s
+}
+
+// Initializes with SeedGen64
+func AtomicPCG32Init() *AtomicPCG32 {
+	return AtomicPCG32New(SeedGen64())
+}
+
+// Generates a random uint32 number
+func (s *AtomicPCG32) Next() u32 {
+	for {
+		state := s.state.Load()
+		if s.state.CompareAndSwap(state, state*pcg32Mul+s.inc) {
+			return RotateU32(u32((state^state>>18)>>27), u8(state>>59))
+		}
+	}
+}
+
+// Generates a random uint64 number
+func (s *AtomicPCG32) Next64() u64 {
+	return u64(s.Next())<<32 | u64(s.Next())
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (s *AtomicPCG32) Range(n int) int {
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *AtomicPCG32) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *AtomicPCG32) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *AtomicPCG32) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *AtomicPCG32) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *AtomicPCG32) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *AtomicPCG32) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
+}
+
+// Generates number in range [0,1]
+func (s *AtomicPCG32) Normal32() f32 {
+	return RandNormal32(s)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (s *AtomicPCG32) Normal64() f64 {
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *AtomicPCG32) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *AtomicPCG32) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *AtomicPCG32) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *AtomicPCG32) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *AtomicPCG32) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *AtomicPCG32) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
+
+// Generator that can derive independent children.
+type Splitter[G any] interface {
+	*G
+	Source
+	Split() G
+}
+
+// Hands each goroutine its own generator, split from a root on demand.
+// Also a Source safe for concurrent use, borrowing a generator per call.
+type Pool[G any, P Splitter[G]] struct {
+	mu   sync.Mutex
+	root G
+	pool sync.Pool
+}
+
+// Splits generators from `root`.
+func PoolNew[G any, P Splitter[G]](root G) *Pool[G, P] {
+	p := &Pool[G, P]{root: root}
+	p.pool.New = func() any {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		child := P(&p.root).Split()
+		return P(&child)
+	}
+	return p
+}
+
+// Borrows a generator for exclusive use until Put.
+func (p *Pool[G, P]) Get() P {
+	return p.pool.Get().(P)
+}
+
+// Returns a generator from Get.
+func (p *Pool[G, P]) Put(s P) {
+	p.pool.Put(s)
+}
+
+// Generates a random uint32 number
+func (p *Pool[G, P]) Next() u32 {
+	s := p.Get()
+	defer p.Put(s)
+	return s.Next()
+}
+
+// Generates a random uint64 number
+func (p *Pool[G, P]) Next64() u64 {
+	s := p.Get()
+	defer p.Put(s)
+	return s.Next64()
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (p *Pool[G, P]) Range(n int) int {
+	return RandRange(p, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (p *Pool[G, P]) Uint32N(n u32) u32 {
+	return RandUint32N(p, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (p *Pool[G, P]) Uint64N(n u64) u64 {
+	return RandUint64N(p, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (p *Pool[G, P]) IntN(n int) int {
+	return RandIntN(p, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (p *Pool[G, P]) Int64N(n s64) s64 {
+	return RandInt64N(p, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (p *Pool[G, P]) IntRange(min, max int) int {
+	return RandIntRange(p, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (p *Pool[G, P]) Int64Range(min, max s64) s64 {
+	return RandInt64Range(p, min, max)
+}
+
+// Generates number in range [0,1]
+func (p *Pool[G, P]) Normal32() f32 {
+	return RandNormal32(p)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (p *Pool[G, P]) Normal64() f64 {
+	return RandNormal64(p)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (p *Pool[G, P]) Float32() f32 {
+	return RandFloat32(p)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (p *Pool[G, P]) Float64() f64 {
+	return RandFloat64(p)
+}
+
+// Generates number in range (0,1)
+func (p *Pool[G, P]) Float32Open() f32 {
+	return RandFloat32Open(p)
+}
+
+// Generates number in range (0,1)
+func (p *Pool[G, P]) Float64Open() f64 {
+	return RandFloat64Open(p)
+}
+
+// Generates number in range [0,1]
+func (p *Pool[G, P]) Float32Closed() f32 {
+	return RandFloat32Closed(p)
+}
+
+// Generates number in range [0,1]
+func (p *Pool[G, P]) Float64Closed() f64 {
+	return RandFloat64Closed(p)
+}
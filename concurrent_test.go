@@ -0,0 +1,58 @@
+package gomisc
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+	"testing"
+)
+
+// Contention of the concurrency-safe generators against the math/rand globals.
+
+func benchmarkParallel(b *testing.B, next func() u32) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			next()
+		}
+	})
+}
+
+func BenchmarkMathRandGlobal(b *testing.B) {
+	benchmarkParallel(b, rand.Uint32)
+}
+
+func BenchmarkMathRandV2Global(b *testing.B) {
+	benchmarkParallel(b, randv2.Uint32)
+}
+
+func BenchmarkLocked(b *testing.B) {
+	s := PCG32New(1)
+	benchmarkParallel(b, LockedNew(&s).Next)
+}
+
+func BenchmarkAtomicMCG32(b *testing.B) {
+	benchmarkParallel(b, AtomicMCG32New(1).Next)
+}
+
+func BenchmarkAtomicPCG32Fast(b *testing.B) {
+	benchmarkParallel(b, AtomicPCG32FastNew(1).Next)
+}
+
+func BenchmarkAtomicPCG32(b *testing.B) {
+	benchmarkParallel(b, AtomicPCG32New(1).Next)
+}
+
+func BenchmarkPool(b *testing.B) {
+	benchmarkParallel(b, PoolNew(PCG32NewStream(1, 1)).Next)
+}
+
+// Each goroutine keeps its own split generator.
+func BenchmarkPoolGetOnce(b *testing.B) {
+	pool := PoolNew(PCG32NewStream(1, 1))
+	b.RunParallel(func(pb *testing.PB) {
+		s := pool.Get()
+		defer pool.Put(s)
+		for pb.Next() {
+			s.Next()
+		}
+	})
+}
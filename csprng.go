@@ -0,0 +1,304 @@
+package gomisc
+
+import cryptorand "crypto/rand"
+
+// ChaCha stream cipher keystream as a generator, see https://cr.yp.to/chacha.html
+// Unpredictable without the key, use ChaCha20 when security matters.
+// The zero value is ChaCha20 with an all zero key.
+type ChaCha struct {
+	key     [8]u32
+	counter u64
+	rounds  int
+	block   [16]u32
+	left    int // Words of block not yet returned
+}
+
+// ChaCha with 8 rounds keyed with `key`, deterministic for tests.
+func ChaCha8New(key [32]u8) ChaCha {
+	return chachaNew(key, 8)
+}
+
+// ChaCha with 20 rounds keyed with `key`, deterministic for tests.
+func ChaCha20New(key [32]u8) ChaCha {
+	return chachaNew(key, 20)
+}
+
+// ChaCha with 8 rounds keyed from crypto/rand.
+func ChaCha8Init() ChaCha {
+	return ChaCha8New(CryptoKey())
+}
+
+// ChaCha with 20 rounds keyed from crypto/rand.
+func ChaCha20Init() ChaCha {
+	return ChaCha20New(CryptoKey())
+}
+
+func chachaNew(key [32]u8, rounds int) ChaCha {
+	s := ChaCha{rounds: rounds}
+	s.Reseed(key)
+	return s
+}
+
+// 32 bytes from crypto/rand.
+func CryptoKey() [32]u8 {
+	var key [32]u8
+	_, err := cryptorand.Read(key[:])
+	PanicErr("crypto/rand failed: ", err)
+	return key
+}
+
+// Rekeys with `key` and restarts the keystream.
+func (s *ChaCha) Reseed(key [32]u8) {
+	for i := range s.key {
+		s.key[i] = U8sToU32(key[i*4:])
+	}
+	s.counter, s.left = 0, 0
+}
+
+// Rekeys by expanding `seed`, which is not secure but reproducible.
+func (s *ChaCha) Seed(seed u64) {
+	var key [32]u8
+	gen := SplitMix64New(seed)
+	for i := 0; i < len(key); i += 8 {
+		copy(key[i:], U64ToU8s(gen.Next64()))
+	}
+	s.Reseed(key)
+}
+
+// Generates a random uint32 number
+func (s *ChaCha) Next() u32 {
+	if s.left == 0 {
+		if s.rounds == 0 {
+			s.rounds = 20
+		}
+		chachaBlock(&s.block, &s.key, s.counter, s.rounds)
+		s.counter++
+		s.left = len(s.block)
+	}
+	s.left--
+	return s.block[len(s.block)-1-s.left]
+}
+
+// Generates a random uint64 number
+func (s *ChaCha) Next64() u64 {
+	return u64(s.Next()) | u64(s.Next())<<32
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (s *ChaCha) Range(n int) int {
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *ChaCha) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *ChaCha) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *ChaCha) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *ChaCha) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *ChaCha) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *ChaCha) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
+}
+
+// Generates number in range [0,1]
+func (s *ChaCha) Normal32() f32 {
+	return RandNormal32(s)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (s *ChaCha) Normal64() f64 {
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *ChaCha) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *ChaCha) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *ChaCha) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *ChaCha) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *ChaCha) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *ChaCha) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
+
+func chachaQuarter(x *[16]u32, a, b, c, d int) {
+	x[a] += x[b]
+	x[d] = RotateU32(x[d]^x[a], 16)
+	x[c] += x[d]
+	x[b] = RotateU32(x[b]^x[c], 12)
+	x[a] += x[b]
+	x[d] = RotateU32(x[d]^x[a], 8)
+	x[c] += x[d]
+	x[b] = RotateU32(x[b]^x[c], 7)
+}
+
+// Keystream block `counter` with a zero nonce.
+func chachaBlock(out *[16]u32, key *[8]u32, counter u64, rounds int) {
+	in := [16]u32{0x61707865, 0x3320646e, 0x79622d32, 0x6b206574}
+	copy(in[4:12], key[:])
+	in[12], in[13] = u32(counter), u32(counter>>32)
+	chachaRounds(out, &in, rounds)
+}
+
+func chachaRounds(out, in *[16]u32, rounds int) {
+	*out = *in
+	for i := 0; i < rounds; i += 2 {
+		chachaQuarter(out, 0, 4, 8, 12)
+		chachaQuarter(out, 1, 5, 9, 13)
+		chachaQuarter(out, 2, 6, 10, 14)
+		chachaQuarter(out, 3, 7, 11, 15)
+		chachaQuarter(out, 0, 5, 10, 15)
+		chachaQuarter(out, 1, 6, 11, 12)
+		chachaQuarter(out, 2, 7, 8, 13)
+		chachaQuarter(out, 3, 4, 9, 14)
+	}
+	for i := range out {
+		out[i] += in[i]
+	}
+}
+
+// Generator reading crypto/rand directly.
+// Not reproducible, use ChaCha20New for deterministic tests.
+// Zero value is ready to use.
+type CryptoRand struct {
+	buffer [64]u8
+	left   int // Bytes of buffer not yet returned
+}
+
+// Initializes with an empty buffer
+func CryptoRandNew() CryptoRand {
+	return CryptoRand{}
+}
+
+// Generates a random uint32 number
+func (s *CryptoRand) Next() u32 {
+	return u32(s.Next64() >> 32)
+}
+
+// Generates a random uint64 number
+func (s *CryptoRand) Next64() u64 {
+	if s.left == 0 {
+		_, err := cryptorand.Read(s.buffer[:])
+		PanicErr("crypto/rand failed: ", err)
+		s.left = len(s.buffer)
+	}
+	s.left -= 8
+	return U8sToU64(s.buffer[s.left:])
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (s *CryptoRand) Range(n int) int {
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *CryptoRand) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *CryptoRand) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *CryptoRand) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *CryptoRand) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *CryptoRand) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *CryptoRand) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
+}
+
+// Generates number in range [0,1]
+func (s *CryptoRand) Normal32() f32 {
+	return RandNormal32(s)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (s *CryptoRand) Normal64() f64 {
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *CryptoRand) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *CryptoRand) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *CryptoRand) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *CryptoRand) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *CryptoRand) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *CryptoRand) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
@@ -0,0 +1,331 @@
+package gomisc
+
+import "sort"
+
+// Path through 2D space over `t` in range [0,1].
+type Curve interface {
+	At(t f64) Vector2
+	Derivative(t f64) Vector2
+}
+
+// Quadratic Bezier curve, passes through the first and last point.
+type Bezier2 [3]Vector2
+
+// Cubic Bezier curve, passes through the first and last point.
+type Bezier3 [4]Vector2
+
+// Point at `t`.
+func (b Bezier2) At(t f64) Vector2 {
+	u := 1 - t
+	return b[0].Mul1(u * u).Add(b[1].Mul1(2 * u * t)).Add(b[2].Mul1(t * t))
+}
+
+// Velocity at `t`.
+func (b Bezier2) Derivative(t f64) Vector2 {
+	return b[1].Sub(b[0]).Mul1(2 * (1 - t)).Add(b[2].Sub(b[1]).Mul1(2 * t))
+}
+
+// Unit direction at `t`.
+func (b Bezier2) Tangent(t f64) Vector2 {
+	return b.Derivative(t).Norm()
+}
+
+// Splits `b` at `t` into 2 curves tracing the same path (de Casteljau).
+func (b Bezier2) Split(t f64) (Bezier2, Bezier2) {
+	p01, p12 := b[0].Lerp(b[1], t), b[1].Lerp(b[2], t)
+	mid := p01.Lerp(p12, t)
+	return Bezier2{b[0], p01, mid}, Bezier2{mid, p12, b[2]}
+}
+
+// Smallest Rect containing `b`.
+func (b Bezier2) Bounds() Rect {
+	result := RectNew(b[0], b[2])
+	for axis := 0; axis < 2; axis++ {
+		// Derivative is linear, a single extreme
+		d := b[0][axis] - 2*b[1][axis] + b[2][axis]
+		if d == 0 {
+			continue
+		}
+		if t := (b[0][axis] - b[1][axis]) / d; t > 0 && t < 1 {
+			result = result.Union(Rect{b.At(t), b.At(t)})
+		}
+	}
+	return result
+}
+
+// Closest point to `p` on `b`, and its `t`.
+func (b Bezier2) ClosestPoint(p Vector2) (Vector2, f64) {
+	return curveClosest(b, p, curveClosestSamples)
+}
+
+// Same curve as a Bezier3.
+func (b Bezier2) Bezier3() Bezier3 {
+	return Bezier3{b[0], b[0].Lerp(b[1], 2./3), b[2].Lerp(b[1], 2./3), b[2]}
+}
+
+// Point at `t`.
+func (b Bezier3) At(t f64) Vector2 {
+	u := 1 - t
+	return b[0].Mul1(u * u * u).Add(b[1].Mul1(3 * u * u * t)).
+		Add(b[2].Mul1(3 * u * t * t)).Add(b[3].Mul1(t * t * t))
+}
+
+// Velocity at `t`.
+func (b Bezier3) Derivative(t f64) Vector2 {
+	return Bezier2{b[1].Sub(b[0]), b[2].Sub(b[1]), b[3].Sub(b[2])}.At(t).Mul1(3)
+}
+
+// Acceleration at `t`.
+func (b Bezier3) SecondDerivative(t f64) Vector2 {
+	d0 := b[2].Sub(b[1].Mul1(2)).Add(b[0])
+	d1 := b[3].Sub(b[2].Mul1(2)).Add(b[1])
+	return d0.Lerp(d1, t).Mul1(6)
+}
+
+// Unit direction at `t`.
+func (b Bezier3) Tangent(t f64) Vector2 {
+	return b.Derivative(t).Norm()
+}
+
+// Splits `b` at `t` into 2 curves tracing the same path (de Casteljau).
+func (b Bezier3) Split(t f64) (Bezier3, Bezier3) {
+	p01, p12, p23 := b[0].Lerp(b[1], t), b[1].Lerp(b[2], t), b[2].Lerp(b[3], t)
+	p012, p123 := p01.Lerp(p12, t), p12.Lerp(p23, t)
+	mid := p012.Lerp(p123, t)
+	return Bezier3{b[0], p01, p012, mid}, Bezier3{mid, p123, p23, b[3]}
+}
+
+// Smallest Rect containing `b`.
+func (b Bezier3) Bounds() Rect {
+	result := RectNew(b[0], b[3])
+	for axis := 0; axis < 2; axis++ {
+		// Roots of the quadratic derivative a*t^2 + b*t + c
+		p0, p1, p2, p3 := b[0][axis], b[1][axis], b[2][axis], b[3][axis]
+		qa := -p0 + 3*p1 - 3*p2 + p3
+		qb := 2 * (p0 - 2*p1 + p2)
+		qc := p1 - p0
+		for _, t := range quadraticRoots(qa, qb, qc) {
+			if t > 0 && t < 1 {
+				result = result.Union(Rect{b.At(t), b.At(t)})
+			}
+		}
+	}
+	return result
+}
+
+// Closest point to `p` on `b`, and its `t`.
+func (b Bezier3) ClosestPoint(p Vector2) (Vector2, f64) {
+	return curveClosest(b, p, curveClosestSamples)
+}
+
+// Real roots of a*x^2 + b*x + c, linear if `a` is 0.
+func quadraticRoots(a, b, c f64) []f64 {
+	if a == 0 {
+		if b == 0 {
+			return nil
+		}
+		return []f64{-c / b}
+	}
+	d := b*b - 4*a*c
+	if d < 0 {
+		return nil
+	}
+	// Avoids cancellation between `b` and the root
+	q := -(b + WithSign(b, Sqrt(d))) / 2
+	if q == 0 {
+		return []f64{0}
+	}
+	return []f64{q / a, c / q}
+}
+
+// Curve from `p0` with velocity `v0` to `p1` with velocity `v1`.
+func Hermite(p0, v0, p1, v1 Vector2) Bezier3 {
+	return Bezier3{p0, p0.Add(v0.Div1(3)), p1.Sub(v1.Div1(3)), p1}
+}
+
+// Catmull-Rom knot spacing exponents.
+const (
+	CatmullRomUniform     = 0.
+	CatmullRomCentripetal = .5
+	CatmullRomChordal     = 1.
+)
+
+// Piecewise cubic curve, each segment takes an equal share of `t`.
+type Spline []Bezier3
+
+// Smooth curve through every point of `points`.
+// `alpha` is the knot spacing, centripetal avoids cusps and self intersections.
+func CatmullRom(points []Vector2, alpha f64) Spline {
+	PanicIf(len(points) < 2, "Catmull-Rom needs at least 2 points")
+	n := len(points)
+	// Mirrored phantom ends keep the end tangents pointing along the path
+	at := func(i int) Vector2 {
+		if i < 0 {
+			return points[0].Mul1(2).Sub(points[1])
+		}
+		if i >= n {
+			return points[n-1].Mul1(2).Sub(points[n-2])
+		}
+		return points[i]
+	}
+	knot := func(a, b Vector2) f64 {
+		if d := Pow(a.Dst(b), alpha); d > 0 {
+			return d
+		}
+		return 1
+	}
+	result := make(Spline, n-1)
+	for i := range result {
+		p0, p1, p2, p3 := at(i-1), at(i), at(i+1), at(i+2)
+		d0, d1, d2 := knot(p0, p1), knot(p1, p2), knot(p2, p3)
+		// Tangents of the Barry-Goldman pyramid, scaled to the segment
+		m1 := p1.Sub(p0).Div1(d0).Sub(p2.Sub(p0).Div1(d0 + d1)).Add(p2.Sub(p1).Div1(d1)).Mul1(d1)
+		m2 := p2.Sub(p1).Div1(d1).Sub(p3.Sub(p1).Div1(d1 + d2)).Add(p3.Sub(p2).Div1(d2)).Mul1(d1)
+		result[i] = Hermite(p1, m1, p2, m2)
+	}
+	return result
+}
+
+// Uniform cubic B-spline of `controls`, smooth but passing near rather than through them.
+func BSpline(controls []Vector2) Spline {
+	PanicIf(len(controls) < 4, "B-spline needs at least 4 control points")
+	result := make(Spline, len(controls)-3)
+	for i := range result {
+		p0, p1, p2, p3 := controls[i], controls[i+1], controls[i+2], controls[i+3]
+		result[i] = Bezier3{
+			p0.Add(p1.Mul1(4)).Add(p2).Div1(6),
+			p1.Mul1(2).Add(p2).Div1(3),
+			p1.Add(p2.Mul1(2)).Div1(3),
+			p1.Add(p2.Mul1(4)).Add(p3).Div1(6),
+		}
+	}
+	return result
+}
+
+// Segment index and its local `t` for spline `t`.
+func (s Spline) segment(t f64) (int, f64) {
+	PanicIf(len(s) == 0, "Empty spline")
+	scaled := Clamp(t, 0, 1) * f64(len(s))
+	i := Min(int(scaled), len(s)-1)
+	return i, scaled - f64(i)
+}
+
+// Point at `t`.
+func (s Spline) At(t f64) Vector2 {
+	i, local := s.segment(t)
+	return s[i].At(local)
+}
+
+// Velocity at `t`.
+func (s Spline) Derivative(t f64) Vector2 {
+	i, local := s.segment(t)
+	return s[i].Derivative(local).Mul1(f64(len(s)))
+}
+
+// Unit direction at `t`.
+func (s Spline) Tangent(t f64) Vector2 {
+	return s.Derivative(t).Norm()
+}
+
+// Splits every segment in half, same path with twice the segments.
+func (s Spline) Subdivide() Spline {
+	result := make(Spline, 0, 2*len(s))
+	for _, b := range s {
+		first, second := b.Split(.5)
+		result = append(result, first, second)
+	}
+	return result
+}
+
+// Smallest Rect containing `s`.
+func (s Spline) Bounds() Rect {
+	result := s[0].Bounds()
+	for _, b := range s[1:] {
+		result = result.Union(b.Bounds())
+	}
+	return result
+}
+
+// Closest point to `p` on `s`, and its `t`.
+func (s Spline) ClosestPoint(p Vector2) (Vector2, f64) {
+	return curveClosest(s, p, curveClosestSamples*len(s))
+}
+
+// Coarse samples per segment before refining the closest point.
+const curveClosestSamples = 16
+
+// Samples `c` `samples` times, then narrows in around the closest sample.
+func curveClosest(c Curve, p Vector2, samples int) (Vector2, f64) {
+	best, bestDst := 0., Inf
+	for i := 0; i <= samples; i++ {
+		t := f64(i) / f64(samples)
+		if d := c.At(t).Sub(p).MagSq(); d < bestDst {
+			best, bestDst = t, d
+		}
+	}
+	// Golden section search, distance is unimodal this close
+	step := 1 / f64(samples)
+	lo, hi := Max(best-step, 0), Min(best+step, 1)
+	const ratio = 0.6180339887498949
+	for hi-lo > 1e-12 {
+		a, b := hi-(hi-lo)*ratio, lo+(hi-lo)*ratio
+		if c.At(a).Sub(p).MagSq() < c.At(b).Sub(p).MagSq() {
+			hi = b
+		} else {
+			lo = a
+		}
+	}
+	t := (lo + hi) / 2
+	if c.At(t).Sub(p).MagSq() > bestDst {
+		t = best
+	}
+	return c.At(t), t
+}
+
+// Arc length lookup table, moves along a curve at constant speed.
+type ArcLength struct {
+	curve   Curve
+	lengths []f64
+}
+
+// Table of `samples` chords along `c`, more samples are more accurate.
+func ArcLengthNew(c Curve, samples int) ArcLength {
+	PanicIf(samples < 1, "Arc length needs at least 1 sample")
+	lengths := make([]f64, samples+1)
+	prev := c.At(0)
+	for i := 1; i <= samples; i++ {
+		p := c.At(f64(i) / f64(samples))
+		lengths[i] = lengths[i-1] + p.Dst(prev)
+		prev = p
+	}
+	return ArcLength{c, lengths}
+}
+
+// Total length of the curve.
+func (a ArcLength) Length() f64 {
+	return a.lengths[len(a.lengths)-1]
+}
+
+// Curve `t` at `distance` along the curve, clamped to its ends.
+func (a ArcLength) T(distance f64) f64 {
+	samples := len(a.lengths) - 1
+	if distance <= 0 || a.Length() == 0 {
+		return 0
+	}
+	if distance >= a.Length() {
+		return 1
+	}
+	i := sort.SearchFloat64s(a.lengths, distance)
+	fraction := (distance - a.lengths[i-1]) / (a.lengths[i] - a.lengths[i-1])
+	return (f64(i-1) + fraction) / f64(samples)
+}
+
+// Point at `distance` along the curve.
+func (a ArcLength) At(distance f64) Vector2 {
+	return a.curve.At(a.T(distance))
+}
+
+// Point at `fraction` of the curve length, in range [0,1].
+func (a ArcLength) AtFraction(fraction f64) Vector2 {
+	return a.At(fraction * a.Length())
+}
@@ -0,0 +1,245 @@
+package gomisc
+
+import "math"
+
+// Ziggurat tables, see Marsaglia & Tsang "The Ziggurat Method for Generating Random Variables"
+const zigR = 3.442619855899
+const zigV = 9.91256303526217e-3
+
+var zigK [128]u32
+var zigW, zigF [128]f64
+
+func init() {
+	const m = 1 << 31
+	d, t := zigR, zigR
+	q := zigV / math.Exp(-.5*d*d)
+	zigK[0], zigK[1] = u32(d/q*m), 0
+	zigW[0], zigW[127] = q/m, d/m
+	zigF[0], zigF[127] = 1, math.Exp(-.5*d*d)
+	for i := 126; i >= 1; i-- {
+		d = math.Sqrt(-2 * math.Log(zigV/d+math.Exp(-.5*d*d)))
+		zigK[i+1] = u32(d / t * m)
+		t = d
+		zigF[i] = math.Exp(-.5 * d * d)
+		zigW[i] = d / m
+	}
+}
+
+func zigNext(s Source) (s32, int, bool) {
+	h := s32(s.Next())
+	i := int(h & 127)
+	return h, i, u32(Abs(s64(h))) < zigK[i]
+}
+
+// Normally distributed number using the Ziggurat method.
+func Gaussian(s Source, mean, stdDev f64) f64 {
+	h, i, ok := zigNext(s)
+	for !ok {
+		x := f64(h) * zigW[i]
+		if i == 0 {
+			// Tail beyond zigR
+			for {
+				x = -math.Log(RandFloat64Open(s)) / zigR
+				y := -math.Log(RandFloat64Open(s))
+				if y+y >= x*x {
+					break
+				}
+			}
+			if h > 0 {
+				return mean + stdDev*(zigR+x)
+			}
+			return mean - stdDev*(zigR+x)
+		}
+		if zigF[i]+RandFloat64(s)*(zigF[i-1]-zigF[i]) < math.Exp(-.5*x*x) {
+			return mean + stdDev*x
+		}
+		h, i, ok = zigNext(s)
+	}
+	return mean + stdDev*f64(h)*zigW[i]
+}
+
+// Normally distributed number using the Box-Muller transform.
+func GaussianBoxMuller(s Source, mean, stdDev f64) f64 {
+	mag := Sqrt(-2 * math.Log(RandFloat64Open(s)))
+	return mean + stdDev*mag*Rad(Tau*RandFloat64(s)).Cos()
+}
+
+// Exponentially distributed number with `rate` events per unit.
+func Exponential(s Source, rate f64) f64 {
+	PanicIf(rate <= 0, "Exponential rate must be positive")
+	return -math.Log(RandFloat64Open(s)) / rate
+}
+
+// Poisson distributed count of events with `mean` expected.
+func Poisson(s Source, mean f64) int {
+	PanicIf(mean < 0, "Poisson mean must be non-negative")
+	if mean < 30 {
+		// Knuth multiplication
+		limit, product, result := math.Exp(-mean), RandFloat64(s), 0
+		for product > limit {
+			product *= RandFloat64(s)
+			result++
+		}
+		return result
+	}
+	// Hörmann "The transformed rejection method for generating Poisson random variables"
+	sqrtMean, logMean := Sqrt(mean), math.Log(mean)
+	b := .931 + 2.53*sqrtMean
+	a := -.059 + .02483*b
+	logAlpha := math.Log(1.1239 + 1.1328/(b-3.4))
+	vr := .9277 - 3.6224/(b-2)
+	for {
+		u, v := RandFloat64(s)-.5, RandFloat64Open(s)
+		us := .5 - Abs(u)
+		k := Floor((2*a/us+b)*u + mean + .43)
+		if us >= .07 && v <= vr {
+			return int(k)
+		}
+		if k < 0 || (us < .013 && v > us) {
+			continue
+		}
+		if math.Log(v)+logAlpha-math.Log(a/(us*us)+b) <= -mean+k*logMean-lgamma(k+1) {
+			return int(k)
+		}
+	}
+}
+
+// Binomially distributed count of successes out of `n` trials with `p` chance.
+func Binomial(s Source, n int, p f64) int {
+	PanicIf(n < 0, "Binomial trials must be non-negative")
+	PanicIf(p < 0 || p > 1, "Binomial chance must be in range [0,1]")
+	if p > .5 {
+		return n - Binomial(s, n, 1-p)
+	}
+	if p == 0 {
+		return 0
+	}
+	q := 1 - p
+	if f64(n)*p < 10 {
+		// Inversion
+		ratio, a := p/q, f64(n+1)*p/q
+		for {
+			r, u := math.Pow(q, f64(n)), RandFloat64(s)
+			result := 0
+			for u > r && result <= n {
+				u -= r
+				result++
+				r *= a/f64(result) - ratio
+			}
+			if result <= n {
+				return result
+			}
+		}
+	}
+	// Hörmann "The generation of binomial random variates"
+	spq := Sqrt(f64(n) * p * q)
+	b := 1.15 + 2.53*spq
+	a := -.0873 + .0248*b + .01*p
+	c := f64(n)*p + .5
+	vr := .92 - 4.2/b
+	alpha := (2.83 + 5.1/b) * spq
+	lpq := math.Log(p / q)
+	m := Floor(f64(n+1) * p)
+	h := lgamma(m+1) + lgamma(f64(n)-m+1)
+	for {
+		u, v := RandFloat64(s)-.5, RandFloat64Open(s)
+		us := .5 - Abs(u)
+		k := Floor((2*a/us+b)*u + c)
+		if k < 0 || k > f64(n) {
+			continue
+		}
+		if us >= .07 && v <= vr {
+			return int(k)
+		}
+		v = math.Log(v * alpha / (a/(us*us) + b))
+		if v <= h-lgamma(k+1)-lgamma(f64(n)-k+1)+(k-m)*lpq {
+			return int(k)
+		}
+	}
+}
+
+// Geometrically distributed count of failures before the first success with `p` chance.
+func Geometric(s Source, p f64) int {
+	PanicIf(p <= 0 || p > 1, "Geometric chance must be in range (0,1]")
+	if p == 1 {
+		return 0
+	}
+	return int(math.Log(RandFloat64Open(s)) / math.Log1p(-p))
+}
+
+// Gamma distributed number using Marsaglia & Tsang method.
+func Gamma(s Source, shape, scale f64) f64 {
+	PanicIf(shape <= 0, "Gamma shape must be positive")
+	PanicIf(scale <= 0, "Gamma scale must be positive")
+	if shape < 1 {
+		return Gamma(s, shape+1, scale) * math.Pow(RandFloat64Open(s), 1/shape)
+	}
+	d := shape - 1./3
+	c := 1 / Sqrt(9*d)
+	for {
+		x := Gaussian(s, 0, 1)
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		if math.Log(RandFloat64Open(s)) < .5*x*x+d-d*v+d*math.Log(v) {
+			return d * v * scale
+		}
+	}
+}
+
+// Beta distributed number in range [0,1].
+func Beta(s Source, a, b f64) f64 {
+	x := Gamma(s, a, 1)
+	return x / (x + Gamma(s, b, 1))
+}
+
+// Cauchy distributed number.
+func Cauchy(s Source, location, scale f64) f64 {
+	return location + scale*math.Tan(Pi*(RandFloat64Open(s)-.5))
+}
+
+// Log-normally distributed number, `mu` and `sigma` of the underlying normal distribution.
+func LogNormal(s Source, mu, sigma f64) f64 {
+	return math.Exp(Gaussian(s, mu, sigma))
+}
+
+// Triangularly distributed number in range [min,max] peaking at `mode`.
+func Triangular(s Source, min, mode, max f64) f64 {
+	PanicIf(min > mode || mode > max, "Triangular requires min <= mode <= max")
+	u, width := RandFloat64(s), max-min
+	if u*width < mode-min {
+		return min + Sqrt(u*width*(mode-min))
+	}
+	return max - Sqrt((1-u)*width*(max-mode))
+}
+
+// Index into `weights` with chance proportional to its weight.
+// Linear in len(weights), see AliasTable for repeated draws.
+func Weighted(s Source, weights []f64) int {
+	total := 0.
+	for _, w := range weights {
+		PanicIf(w < 0, "Weights must be non-negative")
+		total += w
+	}
+	PanicIf(total <= 0, "Weights must sum to a positive value")
+	target := RandFloat64(s) * total
+	for i, w := range weights {
+		if target < w {
+			return i
+		}
+		target -= w
+	}
+	// Rounding error, pick the last non-zero weight
+	for i := len(weights) - 1; ; i-- {
+		if weights[i] > 0 {
+			return i
+		}
+	}
+}
+
+func lgamma(x f64) f64 {
+	result, _ := math.Lgamma(x)
+	return result
+}
@@ -0,0 +1,295 @@
+package gomisc
+
+import "math"
+
+// Easings map time in range [0,1] to progress, 0 at 0 and 1 at 1.
+// In starts slow, Out ends slow, InOut does both (Robert Penner's set).
+
+// `in` played forward then backward over half the time each.
+func easeInOut(in func(f64) f64, t f64) f64 {
+	if t < .5 {
+		return in(2*t) / 2
+	}
+	return 1 - in(2-2*t)/2
+}
+
+// Sine in.
+func EaseInSine(t f64) f64 {
+	return 1 - Cos(t*Pi/2)
+}
+
+// Sine out.
+func EaseOutSine(t f64) f64 {
+	return Sin(t * Pi / 2)
+}
+
+// Sine in and out.
+func EaseInOutSine(t f64) f64 {
+	return (1 - Cos(t*Pi)) / 2
+}
+
+// Quadratic in.
+func EaseInQuad(t f64) f64 {
+	return t * t
+}
+
+// Quadratic out.
+func EaseOutQuad(t f64) f64 {
+	return 1 - EaseInQuad(1-t)
+}
+
+// Quadratic in and out.
+func EaseInOutQuad(t f64) f64 {
+	return easeInOut(EaseInQuad, t)
+}
+
+// Cubic in.
+func EaseInCubic(t f64) f64 {
+	return t * t * t
+}
+
+// Cubic out.
+func EaseOutCubic(t f64) f64 {
+	return 1 - EaseInCubic(1-t)
+}
+
+// Cubic in and out.
+func EaseInOutCubic(t f64) f64 {
+	return easeInOut(EaseInCubic, t)
+}
+
+// Quartic in.
+func EaseInQuart(t f64) f64 {
+	return t * t * t * t
+}
+
+// Quartic out.
+func EaseOutQuart(t f64) f64 {
+	return 1 - EaseInQuart(1-t)
+}
+
+// Quartic in and out.
+func EaseInOutQuart(t f64) f64 {
+	return easeInOut(EaseInQuart, t)
+}
+
+// Quintic in.
+func EaseInQuint(t f64) f64 {
+	return t * t * t * t * t
+}
+
+// Quintic out.
+func EaseOutQuint(t f64) f64 {
+	return 1 - EaseInQuint(1-t)
+}
+
+// Quintic in and out.
+func EaseInOutQuint(t f64) f64 {
+	return easeInOut(EaseInQuint, t)
+}
+
+// Exponential in, exactly 0 at 0.
+func EaseInExpo(t f64) f64 {
+	if t <= 0 {
+		return 0
+	}
+	return math.Exp2(10*t - 10)
+}
+
+// Exponential out, exactly 1 at 1.
+func EaseOutExpo(t f64) f64 {
+	return 1 - EaseInExpo(1-t)
+}
+
+// Exponential in and out.
+func EaseInOutExpo(t f64) f64 {
+	return easeInOut(EaseInExpo, t)
+}
+
+// Circular in.
+func EaseInCirc(t f64) f64 {
+	return 1 - Sqrt(1-t*t)
+}
+
+// Circular out.
+func EaseOutCirc(t f64) f64 {
+	return 1 - EaseInCirc(1-t)
+}
+
+// Circular in and out.
+func EaseInOutCirc(t f64) f64 {
+	return easeInOut(EaseInCirc, t)
+}
+
+// Overshoot of the Back easings, about 10%.
+const easeBack = 1.70158
+
+// Back in, dips below 0 before rising.
+func EaseInBack(t f64) f64 {
+	return t * t * ((easeBack+1)*t - easeBack)
+}
+
+// Back out, overshoots 1 before settling.
+func EaseOutBack(t f64) f64 {
+	return 1 - EaseInBack(1-t)
+}
+
+// Back in and out, with a stronger overshoot to match Penner.
+func EaseInOutBack(t f64) f64 {
+	const c = easeBack * 1.525
+	return easeInOut(func(t f64) f64 { return t * t * ((c+1)*t - c) }, t)
+}
+
+// Elastic in, oscillates with growing amplitude.
+func EaseInElastic(t f64) f64 {
+	if t <= 0 || t >= 1 {
+		return Clamp(t, 0, 1)
+	}
+	return -math.Exp2(10*t-10) * Sin((t*10-10.75)*Tau/3)
+}
+
+// Elastic out, oscillates with shrinking amplitude.
+func EaseOutElastic(t f64) f64 {
+	return 1 - EaseInElastic(1-t)
+}
+
+// Elastic in and out, with a longer period to match Penner.
+func EaseInOutElastic(t f64) f64 {
+	return easeInOut(func(t f64) f64 {
+		if t <= 0 || t >= 1 {
+			return Clamp(t, 0, 1)
+		}
+		return -math.Exp2(10*t-10) * Sin((t*10-11.125)*Tau/4.5)
+	}, t)
+}
+
+// Bounce in.
+func EaseInBounce(t f64) f64 {
+	return 1 - EaseOutBounce(1-t)
+}
+
+// Bounce out, like a ball dropped on the floor.
+func EaseOutBounce(t f64) f64 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + .75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + .9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + .984375
+	}
+}
+
+// Bounce in and out.
+func EaseInOutBounce(t f64) f64 {
+	return easeInOut(EaseInBounce, t)
+}
+
+// CSS cubic-bezier() timing function through (0, 0), (`x1`, `y1`), (`x2`, `y2`), (1, 1).
+// `x1` and `x2` must be in range [0,1] so time never goes back.
+func EaseCubicBezier(x1, y1, x2, y2 f64) func(f64) f64 {
+	PanicIf(x1 < 0 || x1 > 1 || x2 < 0 || x2 > 1, "Cubic bezier x must be in range [0,1]")
+	curve := Bezier3{Vec2(0, 0), Vec2(x1, y1), Vec2(x2, y2), Vec2(1, 1)}
+	return func(x f64) f64 {
+		if x <= 0 || x >= 1 {
+			return Clamp(x, 0, 1)
+		}
+		// Newton's method from x as the guess, bisection where the slope is flat
+		t := x
+		for i := 0; i < 8; i++ {
+			d := curve.At(t)[0] - x
+			if Abs(d) < 1e-12 {
+				return curve.At(t)[1]
+			}
+			slope := curve.Derivative(t)[0]
+			if Abs(slope) < 1e-6 {
+				break
+			}
+			t -= d / slope
+		}
+		lo, hi := 0., 1.
+		for t = x; hi-lo > 1e-12; t = (lo + hi) / 2 {
+			if curve.At(t)[0] < x {
+				lo = t
+			} else {
+				hi = t
+			}
+		}
+		return curve.At(t)[1]
+	}
+}
+
+// CSS ease, ease-in, ease-out and ease-in-out timing functions.
+var (
+	EaseCSS      = EaseCubicBezier(.25, .1, .25, 1)
+	EaseCSSIn    = EaseCubicBezier(.42, 0, 1, 1)
+	EaseCSSOut   = EaseCubicBezier(0, 0, .58, 1)
+	EaseCSSInOut = EaseCubicBezier(.42, 0, .58, 1)
+)
+
+// Where the jumps of EaseSteps happen, like CSS steps().
+type StepPosition int
+
+const (
+	StepJumpEnd   StepPosition = iota // Holds 0 at the start, jumps to 1 at the end
+	StepJumpStart                     // Jumps at the start, holds 1 at the end
+	StepJumpNone                      // Holds both 0 and 1
+	StepJumpBoth                      // Jumps at both ends
+)
+
+// CSS steps() timing function, `count` equal jumps at `position`.
+func EaseSteps(count int, position StepPosition) func(f64) f64 {
+	PanicIf(count < 1 || position == StepJumpNone && count < 2, "Not enough steps")
+	return func(t f64) f64 {
+		if t < 0 || t > 1 {
+			return Clamp(t, 0, 1)
+		}
+		step := Floor(t * f64(count))
+		jumps := f64(count)
+		switch position {
+		case StepJumpStart:
+			step++
+		case StepJumpNone:
+			jumps--
+		case StepJumpBoth:
+			step++
+			jumps++
+		}
+		return Min(step, jumps) / jumps
+	}
+}
+
+// `f` played backward in time and value, In becomes Out.
+func EaseReverse(f func(f64) f64) func(f64) f64 {
+	return func(t f64) f64 {
+		return 1 - f(1-t)
+	}
+}
+
+// `f` forward then back to 0 over the same time.
+func EaseMirror(f func(f64) f64) func(f64) f64 {
+	return func(t f64) f64 {
+		if t < .5 {
+			return f(2 * t)
+		}
+		return f(2 - 2*t)
+	}
+}
+
+// `easings` one after another, each over an equal share of time and progress.
+// EaseChain(EaseInQuad, EaseOutQuad) is EaseInOutQuad.
+func EaseChain(easings ...func(f64) f64) func(f64) f64 {
+	PanicIf(len(easings) == 0, "Nothing to chain")
+	n := f64(len(easings))
+	return func(t f64) f64 {
+		scaled := Clamp(t, 0, 1) * n
+		i := Min(int(scaled), len(easings)-1)
+		return (f64(i) + easings[i](scaled-f64(i))) / n
+	}
+}
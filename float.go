@@ -1,11 +1,18 @@
 package gomisc
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
 
 // Mathematical constants.
 const (
 	E       = 2.7182818284590452353602874713527
 	Phi     = 1.6180339887498948482045868343656
+	Plastic = 1.3247179572447460259609088544781 // Phi generalized to 2 dimensions
 	Pi      = 3.1415926535897932384626433832795
 	Tau     = 6.2831853071795864769252867665590
 	Rad2Deg = 180 / math.Pi
@@ -29,11 +36,30 @@ const F64Sign = 1
 const F64Exponent = 11
 const F64Fraction = 52
 
+// Angle in radians.
 type Rad f64
 
 // Angle in degrees.
-func Deg(value f64) Rad {
-	return Rad(value * Deg2Rad)
+type Deg f64
+
+// Degrees to radians.
+func (d Deg) Rad() Rad {
+	return Rad(d * Deg2Rad)
+}
+
+// Degrees `d` as radians.
+func DegToRad(d f64) Rad {
+	return Deg(d).Rad()
+}
+
+// Radians `r` as degrees.
+func RadToDeg(r f64) Deg {
+	return Rad(r).Deg()
+}
+
+// Radians to degrees.
+func (r Rad) Deg() Deg {
+	return Deg(r * Rad2Deg)
 }
 
 // Radian cosine.
@@ -46,6 +72,114 @@ func (r Rad) Sin() f64 {
 	return Sin(f64(r))
 }
 
+// Radian tangent.
+func (r Rad) Tan() f64 {
+	return Tan(f64(r))
+}
+
+// Same angle in range [-Pi,Pi).
+func (r Rad) Normalize() Rad {
+	result := r - Tau*Rad(Floor(f64(r+Pi)/Tau))
+	if result >= Pi {
+		// Rounding can land exactly on the excluded end
+		result -= Tau
+	}
+	return result
+}
+
+// Same angle in range [0,Tau).
+func (r Rad) NormalizePositive() Rad {
+	result := r - Tau*Rad(Floor(f64(r)/Tau))
+	if result >= Tau {
+		result -= Tau
+	}
+	return result
+}
+
+// Shortest signed turn from `r` to `other`, in range [-Pi,Pi).
+func (r Rad) Delta(other Rad) Rad {
+	return (other - r).Normalize()
+}
+
+// Interpolates towards `other` along the shortest arc.
+func (r Rad) Lerp(other Rad, t f64) Rad {
+	return r + r.Delta(other)*Rad(t)
+}
+
+// Turns towards `other` along the shortest arc by at most `dlt`, which must not be negative.
+func (r Rad) MoveTowards(other, dlt Rad) Rad {
+	PanicIf(dlt < 0, "MoveTowards delta must not be negative")
+	delta := r.Delta(other)
+	if Abs(f64(delta)) <= f64(dlt) {
+		return r + delta
+	}
+	return r + Rad(WithSign(f64(delta), f64(dlt)))
+}
+
+// Degrees, like "90°".
+func (r Rad) String() string {
+	return r.Deg().String()
+}
+
+// Formats degrees with float verbs, like "%.1f" giving "90.0°".
+func (r Rad) Format(f fmt.State, verb rune) {
+	r.Deg().Format(f, verb)
+}
+
+// Like "90°".
+func (d Deg) String() string {
+	return strconv.FormatFloat(f64(d), 'g', -1, 64) + "°"
+}
+
+// Formats with float verbs, like "%.1f" giving "90.0°".
+func (d Deg) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		if _, ok := f.Precision(); !ok {
+			fmt.Fprint(f, padDegrees(f, d.String()))
+			return
+		}
+		verb = 'g'
+	case 'b', 'e', 'E', 'f', 'F', 'g', 'G', 'x', 'X':
+	default:
+		fmt.Fprintf(f, "%%!%c(gomisc.Deg=%s)", verb, d.String())
+		return
+	}
+	format := "%"
+	for _, flag := range "+ #" {
+		if f.Flag(int(flag)) {
+			format += string(flag)
+		}
+	}
+	if precision, ok := f.Precision(); ok {
+		format += "." + strconv.Itoa(precision)
+	}
+	fmt.Fprint(f, padDegrees(f, fmt.Sprintf(format+string(verb), f64(d))+"°"))
+}
+
+// `text` padded to the width of `f`, zeros go after the sign like for floats.
+func padDegrees(f fmt.State, text string) string {
+	width, ok := f.Width()
+	if !ok {
+		return text
+	}
+	pad := width - utf8.RuneCountInString(text)
+	if pad <= 0 {
+		return text
+	}
+	if f.Flag('-') {
+		return text + strings.Repeat(" ", pad)
+	}
+	if f.Flag('0') && !strings.ContainsAny(text, "IN") {
+		sign := 0
+		if strings.ContainsRune("+- ", rune(text[0])) {
+			sign = 1
+		}
+		return text[:sign] + strings.Repeat("0", pad) + text[sign:]
+	}
+	return strings.Repeat(" ", pad) + text
+}
+
 // Float32 bits
 var F32ToU32 = math.Float32bits
 
@@ -93,12 +227,21 @@ func PartsToF64(sign bool, exponent u16, fraction u64) f64 {
 // Radian cosine.
 var Cos = math.Cos
 
-// Radian cosine.
+// Radian sine.
 var Sin = math.Sin
 
+// Radian tangent.
+var Tan = math.Tan
+
 // Origin to point angle.
 var Atan2 = math.Atan2
 
+// Radian arc cosine.
+var Acos = math.Acos
+
+// Positive infinity.
+var Inf = math.Inf(1)
+
 // Sign of `num`.
 func Sign[T Float](num T) T {
 	if num < 0 {
@@ -0,0 +1,41 @@
+package gomisc
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestDegToRad(t *testing.T) {
+	if got := DegToRad(180); got != Pi {
+		t.Errorf("DegToRad(180) = %v", f64(got))
+	}
+	if got := RadToDeg(Pi); got != 180 {
+		t.Errorf("RadToDeg(Pi) = %v", f64(got))
+	}
+}
+
+func TestDegFormat(t *testing.T) {
+	for _, c := range []struct {
+		format string
+		value  Deg
+		want   string
+	}{
+		{"%v", 90, "90°"},
+		{"%.1f", 90, "90.0°"},
+		{"%6v", 90, "   90°"},
+		{"%-6v|", 90, "90°   |"},
+		{"%06v", 90, "00090°"},
+		{"%07.1f", -90, "-090.0°"},
+		{"%+07.1f", 90, "+090.0°"},
+		{"%06v", Deg(math.Inf(1)), " +Inf°"},
+		{"%-06v|", 90, "90°   |"},
+	} {
+		if got := fmt.Sprintf(c.format, c.value); got != c.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", c.format, f64(c.value), got, c.want)
+		}
+	}
+	if got := fmt.Sprintf("%07.1f", Rad(Pi)); got != "0180.0°" {
+		t.Errorf("Rad = %q", got)
+	}
+}
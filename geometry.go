@@ -0,0 +1,479 @@
+package gomisc
+
+// Straight path between 2 points.
+type Segment struct {
+	A, B Vector2
+}
+
+// Half-infinite line from `Origin` along `Dir`.
+// `Dir` must be unit, RayNew normalizes it.
+type Ray struct {
+	Origin, Dir Vector2
+}
+
+// Infinite line through `Point` along `Dir`.
+// `Dir` must be unit, LineNew normalizes it.
+type Line struct {
+	Point, Dir Vector2
+}
+
+// Disc of `Radius` around `Center`.
+type Circle struct {
+	Center Vector2
+	Radius f64
+}
+
+// Axis aligned bounding box.
+type Rect struct {
+	Min, Max Vector2
+}
+
+// Oriented bounding box, a Rect rotated around its center.
+type OBB struct {
+	Center, HalfSize Vector2
+	Rotation         Rad
+}
+
+// 3 corners.
+type Triangle [3]Vector2
+
+// Simple (not self intersecting) polygon, convex or concave, in either winding.
+type Polygon []Vector2
+
+// Closest point to `p` on `s`, and its position along `s` in range [0,1].
+func (s Segment) closest(p Vector2) (Vector2, f64) {
+	dir := s.B.Sub(s.A)
+	lenSq := dir.MagSq()
+	if lenSq == 0 {
+		return s.A, 0
+	}
+	t := Clamp(p.Sub(s.A).Dot(dir)/lenSq, 0, 1)
+	return dir.Mul1(t).Add(s.A), t
+}
+
+// Closest point to `p` on `s`.
+func (s Segment) ClosestPoint(p Vector2) Vector2 {
+	result, _ := s.closest(p)
+	return result
+}
+
+// Distance between `p` and `s`.
+func (s Segment) Dst(p Vector2) f64 {
+	return s.ClosestPoint(p).Dst(p)
+}
+
+// Length of `s`.
+func (s Segment) Len() f64 {
+	return s.A.Dst(s.B)
+}
+
+// Unit normal, on the left side going from A to B.
+func (s Segment) Normal() Vector2 {
+	return s.B.Sub(s.A).Rot90().Norm()
+}
+
+// Crossing point of `s` and `other`.
+// For collinear overlapping segments, the overlap point closest to `s.A`.
+func (s Segment) Intersect(other Segment) (Vector2, bool) {
+	r, q := s.B.Sub(s.A), other.B.Sub(other.A)
+	offset := other.A.Sub(s.A)
+	denom := r.Cross(q)
+	if denom == 0 {
+		if offset.Cross(r) != 0 || r.MagSq() == 0 {
+			return Vector2{}, false
+		}
+		// Collinear, overlap of the projections onto `r`
+		t0 := offset.Dot(r) / r.MagSq()
+		t1 := other.B.Sub(s.A).Dot(r) / r.MagSq()
+		start, end := Max(0, Min(t0, t1)), Min(1, Max(t0, t1))
+		if start > end {
+			return Vector2{}, false
+		}
+		return r.Mul1(start).Add(s.A), true
+	}
+	t, u := offset.Cross(q)/denom, offset.Cross(r)/denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Vector2{}, false
+	}
+	return r.Mul1(t).Add(s.A), true
+}
+
+// Ray from `origin` towards `dir`, normalized.
+func RayNew(origin, dir Vector2) Ray {
+	return Ray{origin, dir.Norm()}
+}
+
+// Point at distance `t` along `r`.
+func (r Ray) At(t f64) Vector2 {
+	return r.Dir.Mul1(t).Add(r.Origin)
+}
+
+// Closest point to `p` on `r`.
+func (r Ray) ClosestPoint(p Vector2) Vector2 {
+	return r.At(Max(0, p.Sub(r.Origin).Dot(r.Dir)))
+}
+
+// Distance between `p` and `r`.
+func (r Ray) Dst(p Vector2) f64 {
+	return r.ClosestPoint(p).Dst(p)
+}
+
+// Where a ray hit a shape.
+type RayHit struct {
+	Point, Normal Vector2
+	Dst           f64 // Along the ray
+}
+
+// First hit of `r` on `s`, normal facing the ray.
+func (r Ray) CastSegment(s Segment) (RayHit, bool) {
+	q := s.B.Sub(s.A)
+	denom := r.Dir.Cross(q)
+	if denom == 0 {
+		return RayHit{}, false
+	}
+	offset := s.A.Sub(r.Origin)
+	t, u := offset.Cross(q)/denom, offset.Cross(r.Dir)/denom
+	if t < 0 || u < 0 || u > 1 {
+		return RayHit{}, false
+	}
+	normal := q.Rot90().Norm()
+	if normal.Dot(r.Dir) > 0 {
+		normal = normal.Neg()
+	}
+	return RayHit{r.At(t), normal, t}, true
+}
+
+// First hit of `r` on the outline of `c`, normal facing outwards.
+func (r Ray) CastCircle(c Circle) (RayHit, bool) {
+	offset := r.Origin.Sub(c.Center)
+	b := offset.Dot(r.Dir)
+	discriminant := b*b - offset.MagSq() + c.Radius*c.Radius
+	if discriminant < 0 {
+		return RayHit{}, false
+	}
+	root := Sqrt(discriminant)
+	t := -b - root
+	if t < 0 {
+		// Starting inside, hit the far side
+		if t = -b + root; t < 0 {
+			return RayHit{}, false
+		}
+	}
+	point := r.At(t)
+	return RayHit{point, point.Sub(c.Center).Norm(), t}, true
+}
+
+// First hit of `r` on `rect`, normal facing outwards (slab method).
+// Starting inside hits at distance 0.
+func (r Ray) CastRect(rect Rect) (RayHit, bool) {
+	near, far := 0., Inf
+	var normal Vector2
+	for axis := 0; axis < 2; axis++ {
+		if r.Dir[axis] == 0 {
+			if r.Origin[axis] < rect.Min[axis] || r.Origin[axis] > rect.Max[axis] {
+				return RayHit{}, false
+			}
+			continue
+		}
+		t0 := (rect.Min[axis] - r.Origin[axis]) / r.Dir[axis]
+		t1 := (rect.Max[axis] - r.Origin[axis]) / r.Dir[axis]
+		side := -1.
+		if t0 > t1 {
+			t0, t1, side = t1, t0, 1
+		}
+		if t0 > near {
+			near = t0
+			normal = Vector2{}
+			normal[axis] = side
+		}
+		far = Min(far, t1)
+		if near > far {
+			return RayHit{}, false
+		}
+	}
+	return RayHit{r.At(near), normal, near}, true
+}
+
+// First hit of `r` on `o`, normal facing outwards.
+// Starting inside hits at distance 0.
+func (r Ray) CastOBB(o OBB) (RayHit, bool) {
+	local := Ray{r.Origin.Sub(o.Center).Rot(-o.Rotation), r.Dir.Rot(-o.Rotation)}
+	hit, ok := local.CastRect(Rect{o.HalfSize.Neg(), o.HalfSize})
+	if !ok {
+		return RayHit{}, false
+	}
+	return RayHit{hit.Point.Rot(o.Rotation).Add(o.Center), hit.Normal.Rot(o.Rotation), hit.Dst}, true
+}
+
+// First hit of `r` on the outline of `t`.
+func (r Ray) CastTriangle(t Triangle) (RayHit, bool) {
+	return r.CastPolygon(t.Polygon())
+}
+
+// First hit of `r` on the outline of `p`.
+func (r Ray) CastPolygon(p Polygon) (RayHit, bool) {
+	var result RayHit
+	found := false
+	for _, edge := range p.Edges() {
+		if hit, ok := r.CastSegment(edge); ok && (!found || hit.Dst < result.Dst) {
+			result, found = hit, true
+		}
+	}
+	return result, found
+}
+
+// Line through `a` and `b`.
+func LineNew(a, b Vector2) Line {
+	return Line{a, b.Sub(a).Norm()}
+}
+
+// Closest point to `p` on `l`.
+func (l Line) ClosestPoint(p Vector2) Vector2 {
+	return l.Dir.Mul1(p.Sub(l.Point).Dot(l.Dir)).Add(l.Point)
+}
+
+// Distance between `p` and `l`, positive on the left side.
+func (l Line) SignedDst(p Vector2) f64 {
+	return l.Dir.Cross(p.Sub(l.Point))
+}
+
+// Distance between `p` and `l`.
+func (l Line) Dst(p Vector2) f64 {
+	return Abs(l.SignedDst(p))
+}
+
+// Crossing point of `l` and `other`, false if parallel.
+func (l Line) Intersect(other Line) (Vector2, bool) {
+	denom := l.Dir.Cross(other.Dir)
+	if denom == 0 {
+		return Vector2{}, false
+	}
+	t := other.Point.Sub(l.Point).Cross(other.Dir) / denom
+	return l.Dir.Mul1(t).Add(l.Point), true
+}
+
+// Is `p` inside `c`.
+func (c Circle) Contains(p Vector2) bool {
+	return p.Sub(c.Center).MagSq() <= c.Radius*c.Radius
+}
+
+// Closest point to `p` in `c`, `p` itself if inside.
+func (c Circle) ClosestPoint(p Vector2) Vector2 {
+	return p.Sub(c.Center).ClampMag(c.Radius).Add(c.Center)
+}
+
+// Signed distance from the outline of `c`, negative inside.
+func (c Circle) SDF(p Vector2) f64 {
+	return p.Dst(c.Center) - c.Radius
+}
+
+// Area of `c`.
+func (c Circle) Area() f64 {
+	return Pi * c.Radius * c.Radius
+}
+
+// Smallest Rect containing `c`.
+func (c Circle) Bounds() Rect {
+	return Rect{c.Center.Sub1(c.Radius), c.Center.Add1(c.Radius)}
+}
+
+// Rect with corners `a` and `b`, in any order.
+func RectNew(a, b Vector2) Rect {
+	return Rect{Vec2(Min(a[0], b[0]), Min(a[1], b[1])), Vec2(Max(a[0], b[0]), Max(a[1], b[1]))}
+}
+
+// Rect of `size` around `center`.
+func RectCentered(center, size Vector2) Rect {
+	half := size.Abs().Mul1(.5)
+	return Rect{center.Sub(half), center.Add(half)}
+}
+
+// Middle of `r`.
+func (r Rect) Center() Vector2 {
+	return r.Min.Lerp(r.Max, .5)
+}
+
+// Width and height of `r`.
+func (r Rect) Size() Vector2 {
+	return r.Max.Sub(r.Min)
+}
+
+// Area of `r`.
+func (r Rect) Area() f64 {
+	size := r.Size()
+	return size[0] * size[1]
+}
+
+// Is `p` inside `r`.
+func (r Rect) Contains(p Vector2) bool {
+	return p[0] >= r.Min[0] && p[1] >= r.Min[1] && p[0] <= r.Max[0] && p[1] <= r.Max[1]
+}
+
+// Is `other` entirely inside `r`.
+func (r Rect) ContainsRect(other Rect) bool {
+	return r.Contains(other.Min) && r.Contains(other.Max)
+}
+
+// Closest point to `p` in `r`, `p` itself if inside.
+func (r Rect) ClosestPoint(p Vector2) Vector2 {
+	return Vec2(Clamp(p[0], r.Min[0], r.Max[0]), Clamp(p[1], r.Min[1], r.Max[1]))
+}
+
+// Signed distance from the outline of `r`, negative inside.
+func (r Rect) SDF(p Vector2) f64 {
+	d := p.Sub(r.Center()).Abs().Sub(r.Size().Mul1(.5))
+	outside := Vec2(Max(d[0], 0), Max(d[1], 0)).Mag()
+	return outside + Min(d.Max(), 0)
+}
+
+// Do `r` and `other` share any point.
+func (r Rect) Overlaps(other Rect) bool {
+	return r.Min[0] <= other.Max[0] && other.Min[0] <= r.Max[0] &&
+		r.Min[1] <= other.Max[1] && other.Min[1] <= r.Max[1]
+}
+
+// Shared area of `r` and `other`, false if they don't overlap.
+func (r Rect) Intersection(other Rect) (Rect, bool) {
+	result := Rect{
+		Vec2(Max(r.Min[0], other.Min[0]), Max(r.Min[1], other.Min[1])),
+		Vec2(Min(r.Max[0], other.Max[0]), Min(r.Max[1], other.Max[1])),
+	}
+	return result, result.Min[0] <= result.Max[0] && result.Min[1] <= result.Max[1]
+}
+
+// Smallest Rect containing `r` and `other`.
+func (r Rect) Union(other Rect) Rect {
+	return Rect{
+		Vec2(Min(r.Min[0], other.Min[0]), Min(r.Min[1], other.Min[1])),
+		Vec2(Max(r.Max[0], other.Max[0]), Max(r.Max[1], other.Max[1])),
+	}
+}
+
+// Grows each side of `r` by `amount`.
+func (r Rect) Expand(amount f64) Rect {
+	return Rect{r.Min.Sub1(amount), r.Max.Add1(amount)}
+}
+
+// Corners of `r`, counter-clockwise.
+func (r Rect) Polygon() Polygon {
+	return Polygon{r.Min, Vec2(r.Max[0], r.Min[1]), r.Max, Vec2(r.Min[0], r.Max[1])}
+}
+
+// `p` relative to the center and rotation of `o`.
+func (o OBB) toLocal(p Vector2) Vector2 {
+	return p.Sub(o.Center).Rot(-o.Rotation)
+}
+
+// Is `p` inside `o`.
+func (o OBB) Contains(p Vector2) bool {
+	local := o.toLocal(p).Abs()
+	return local[0] <= o.HalfSize[0] && local[1] <= o.HalfSize[1]
+}
+
+// Closest point to `p` in `o`, `p` itself if inside.
+func (o OBB) ClosestPoint(p Vector2) Vector2 {
+	local := Rect{o.HalfSize.Neg(), o.HalfSize}.ClosestPoint(o.toLocal(p))
+	return local.Rot(o.Rotation).Add(o.Center)
+}
+
+// Signed distance from the outline of `o`, negative inside.
+func (o OBB) SDF(p Vector2) f64 {
+	return Rect{o.HalfSize.Neg(), o.HalfSize}.SDF(o.toLocal(p))
+}
+
+// Corners of `o`, counter-clockwise.
+func (o OBB) Polygon() Polygon {
+	x, y := Vec2(o.HalfSize[0], 0).Rot(o.Rotation), Vec2(0, o.HalfSize[1]).Rot(o.Rotation)
+	return Polygon{
+		o.Center.Sub(x).Sub(y), o.Center.Add(x).Sub(y),
+		o.Center.Add(x).Add(y), o.Center.Sub(x).Add(y),
+	}
+}
+
+// Smallest Rect containing `o`.
+func (o OBB) Bounds() Rect {
+	return o.Polygon().Bounds()
+}
+
+// Area of `t`.
+func (t Triangle) Area() f64 {
+	return Abs(t[1].Sub(t[0]).Cross(t[2].Sub(t[0]))) / 2
+}
+
+// Is `p` inside `t`, in either winding.
+func (t Triangle) Contains(p Vector2) bool {
+	d0 := t[1].Sub(t[0]).Cross(p.Sub(t[0]))
+	d1 := t[2].Sub(t[1]).Cross(p.Sub(t[1]))
+	d2 := t[0].Sub(t[2]).Cross(p.Sub(t[2]))
+	return !((d0 < 0 || d1 < 0 || d2 < 0) && (d0 > 0 || d1 > 0 || d2 > 0))
+}
+
+// Closest point to `p` in `t`, `p` itself if inside.
+func (t Triangle) ClosestPoint(p Vector2) Vector2 {
+	if t.Contains(p) {
+		return p
+	}
+	return t.Polygon().closestOnOutline(p)
+}
+
+// Signed distance from the outline of `t`, negative inside.
+func (t Triangle) SDF(p Vector2) f64 {
+	return t.Polygon().SDF(p)
+}
+
+// Center of mass of `t`.
+func (t Triangle) Centroid() Vector2 {
+	return t[0].Add(t[1]).Add(t[2]).Div1(3)
+}
+
+// Corners of `t`.
+func (t Triangle) Polygon() Polygon {
+	return Polygon{t[0], t[1], t[2]}
+}
+
+// Outline of `p`, from each vertex to the next.
+func (p Polygon) Edges() []Segment {
+	result := make([]Segment, len(p))
+	for i, a := range p {
+		result[i] = Segment{a, p[(i+1)%len(p)]}
+	}
+	return result
+}
+
+// Smallest Rect containing `p`.
+func (p Polygon) Bounds() Rect {
+	min, max := polygonBounds(p)
+	return Rect{min, max}
+}
+
+// Is `point` inside `p` (even-odd rule).
+func (p Polygon) Contains(point Vector2) bool {
+	return polygonContains(p, point)
+}
+
+func (p Polygon) closestOnOutline(point Vector2) Vector2 {
+	result, best := p[0], Inf
+	for _, edge := range p.Edges() {
+		if c := edge.ClosestPoint(point); c.Dst(point) < best {
+			result, best = c, c.Dst(point)
+		}
+	}
+	return result
+}
+
+// Closest point to `point` in `p`, `point` itself if inside.
+func (p Polygon) ClosestPoint(point Vector2) Vector2 {
+	if p.Contains(point) {
+		return point
+	}
+	return p.closestOnOutline(point)
+}
+
+// Signed distance from the outline of `p`, negative inside.
+func (p Polygon) SDF(point Vector2) f64 {
+	d := p.closestOnOutline(point).Dst(point)
+	if p.Contains(point) {
+		return -d
+	}
+	return d
+}
@@ -1,3 +1,3 @@
 module github.com/IgneousRed/gomisc
 
-go 1.19
+go 1.22
@@ -0,0 +1,167 @@
+package gomisc
+
+import "hash"
+
+var (
+	_ hash.Hash32 = (*Hash32)(nil)
+	_ hash.Hash64 = (*Hash64)(nil)
+)
+
+// Hashes `data` with length finalization.
+func Hash32Bytes(data []u8) u32 {
+	h := Hash32Empty()
+	h.Write(data)
+	return h.Sum32()
+}
+
+// Hashes `value` with length finalization.
+func Hash32String(value string) u32 {
+	return Hash32Bytes([]u8(value))
+}
+
+// Mix in bytes, buffering a partial word until more arrive.
+// A zero value starts as the empty hash. Never returns an error.
+func (h *Hash32) Write(data []u8) (int, error) {
+	if h.hashNum == 0 {
+		*h = Hash32Empty()
+	}
+	n := len(data)
+	h.length += u64(n)
+	if h.tailLen > 0 {
+		for ; h.tailLen < 4 && len(data) > 0; data = data[1:] {
+			h.tail |= u32(data[0]) << (8 * h.tailLen)
+			h.tailLen++
+		}
+		if h.tailLen < 4 {
+			return n, nil
+		}
+		*h = h.Mix(h.tail)
+		h.tail, h.tailLen = 0, 0
+	}
+	for ; len(data) >= 4; data = data[4:] {
+		*h = h.Mix(U8sToU32(data))
+	}
+	for i, b := range data {
+		h.tail |= u32(b) << (8 * i)
+	}
+	h.tailLen = u8(len(data))
+	return n, nil
+}
+
+// Mix in string bytes.
+func (h *Hash32) WriteString(value string) (int, error) {
+	return h.Write([]u8(value))
+}
+
+// Result of written bytes, finalized with the pending bytes and length.
+func (h Hash32) Sum32() u32 {
+	if h.hashNum == 0 {
+		h = Hash32Empty()
+	}
+	if h.tailLen > 0 {
+		h = h.Mix(h.tail)
+	}
+	return h.Mix(u32(h.length)).Mix(u32(h.length >> 32)).Result()
+}
+
+// Appends big-endian Sum32 to `b`.
+func (h Hash32) Sum(b []u8) []u8 {
+	sum := h.Sum32()
+	return append(b, u8(sum>>24), u8(sum>>16), u8(sum>>8), u8(sum))
+}
+
+// Back to Hash32Empty.
+func (h *Hash32) Reset() {
+	*h = Hash32Empty()
+}
+
+// Bytes returned by Sum.
+func (h Hash32) Size() int {
+	return 4
+}
+
+// Bytes mixed at once.
+func (h Hash32) BlockSize() int {
+	return 4
+}
+
+// Hashes `data` with length finalization.
+func Hash64Bytes(data []u8) u64 {
+	h := Hash64Empty()
+	h.Write(data)
+	return h.Sum64()
+}
+
+// Hashes `value` with length finalization.
+func Hash64String(value string) u64 {
+	return Hash64Bytes([]u8(value))
+}
+
+// Mix in bytes, buffering a partial word until more arrive.
+// A zero value starts as the empty hash. Never returns an error.
+func (h *Hash64) Write(data []u8) (int, error) {
+	if h.hashNum == 0 {
+		*h = Hash64Empty()
+	}
+	n := len(data)
+	h.length += u64(n)
+	if h.tailLen > 0 {
+		for ; h.tailLen < 8 && len(data) > 0; data = data[1:] {
+			h.tail |= u64(data[0]) << (8 * h.tailLen)
+			h.tailLen++
+		}
+		if h.tailLen < 8 {
+			return n, nil
+		}
+		*h = h.Mix(h.tail)
+		h.tail, h.tailLen = 0, 0
+	}
+	for ; len(data) >= 8; data = data[8:] {
+		*h = h.Mix(U8sToU64(data))
+	}
+	for i, b := range data {
+		h.tail |= u64(b) << (8 * i)
+	}
+	h.tailLen = u8(len(data))
+	return n, nil
+}
+
+// Mix in string bytes.
+func (h *Hash64) WriteString(value string) (int, error) {
+	return h.Write([]u8(value))
+}
+
+// Result of written bytes, finalized with the pending bytes and length.
+func (h Hash64) Sum64() u64 {
+	if h.hashNum == 0 {
+		h = Hash64Empty()
+	}
+	if h.tailLen > 0 {
+		h = h.Mix(h.tail)
+	}
+	return h.Mix(h.length).Result()
+}
+
+// Appends big-endian Sum64 to `b`.
+func (h Hash64) Sum(b []u8) []u8 {
+	sum := h.Sum64()
+	return append(b,
+		u8(sum>>56), u8(sum>>48), u8(sum>>40), u8(sum>>32),
+		u8(sum>>24), u8(sum>>16), u8(sum>>8), u8(sum),
+	)
+}
+
+// Back to Hash64Empty.
+func (h *Hash64) Reset() {
+	*h = Hash64Empty()
+}
+
+// Bytes returned by Sum.
+func (h Hash64) Size() int {
+	return 8
+}
+
+// Bytes mixed at once.
+func (h Hash64) BlockSize() int {
+	return 8
+}
@@ -0,0 +1,45 @@
+package gomisc
+
+import "testing"
+
+// Hash outputs feed saved seeds and Noise, changing them is a breaking change.
+
+func TestHash32Golden(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		got, want u32
+	}{
+		{"Hash32From(0)", Hash32From(0), 0xeaa2baf9},
+		{"Hash32From(1, 2, 3)", Hash32From(1, 2, 3), 0x90b029d8},
+		{"Hash32New(7).Mix(8)", Hash32New(7).Mix(8).Result(), 0xaba328de},
+		{"Hash32String(hello)", Hash32String("hello"), 0xf1e74c03},
+		{"Hash32Bytes(nil)", Hash32Bytes(nil), 0xe0203007},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s = %#x, want %#x", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestHash64Golden(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		got, want u64
+	}{
+		{"Hash64From(0)", Hash64From(0), 0xff9d36db40303d6d},
+		{"Hash64From(1, 2, 3)", Hash64From(1, 2, 3), 0xb389261e414be3ab},
+		{"Hash64New(7).Mix(8)", Hash64New(7).Mix(8).Result(), 0x1ace49c39162a1f4},
+		{"Hash64String(hello)", Hash64String("hello"), 0x764ed60f1cfe2fdd},
+		{"Hash64Bytes(nil)", Hash64Bytes(nil), 0xff9d36db40303d6d},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s = %#x, want %#x", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestNoiseGolden(t *testing.T) {
+	if got := NoiseNew(1); got != 2294970221 {
+		t.Errorf("NoiseNew(1) = %v, want 2294970221", got)
+	}
+}
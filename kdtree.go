@@ -0,0 +1,129 @@
+package gomisc
+
+import "sort"
+
+// Balanced tree alternating x and y splits, built once from a fixed set.
+// Fastest queries of the spatial indexes, rebuild instead of moving entries.
+// IDs are indices into the slices it was built from, which must not change.
+type KDTree[T any] struct {
+	// Implicit tree, the median of each range is its node
+	ids    []SpatialID
+	points []Vector2
+	values []T
+}
+
+// Tree over `points` with matching `values`, nil `values` means all zero.
+func KDTreeNew[T any](points []Vector2, values []T) KDTree[T] {
+	PanicIf(values != nil && len(values) != len(points), "Points and values lengths differ")
+	if values == nil {
+		values = make([]T, len(points))
+	}
+	ids := make([]SpatialID, len(points))
+	for i := range ids {
+		ids[i] = SpatialID(i)
+	}
+	kdBuild(ids, points, 0)
+	return KDTree[T]{ids, points, values}
+}
+
+// Orders `ids` so each range median splits it on `axis`, alternating below.
+func kdBuild(ids []SpatialID, points []Vector2, axis int) {
+	if len(ids) <= 1 {
+		return
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return points[ids[i]][axis] < points[ids[j]][axis]
+	})
+	mid := len(ids) / 2
+	kdBuild(ids[:mid], points, axis^1)
+	kdBuild(ids[mid+1:], points, axis^1)
+}
+
+// Entries in `t`.
+func (t *KDTree[T]) Len() int {
+	return len(t.ids)
+}
+
+// Position and value of entry `id`.
+func (t *KDTree[T]) Get(id SpatialID) (Vector2, T) {
+	return t.points[id], t.values[id]
+}
+
+// Calls `f` for each entry in `rect` until it returns false.
+func (t *KDTree[T]) Rect(rect Rect, f func(id SpatialID, pos Vector2, value T) bool) {
+	t.rect(t.ids, 0, rect, f)
+}
+
+func (t *KDTree[T]) rect(ids []SpatialID, axis int, rect Rect,
+	f func(id SpatialID, pos Vector2, value T) bool,
+) bool {
+	if len(ids) == 0 {
+		return true
+	}
+	mid := len(ids) / 2
+	id := ids[mid]
+	p := t.points[id]
+	if rect.Contains(p) && !f(id, p, t.values[id]) {
+		return false
+	}
+	if rect.Min[axis] <= p[axis] && !t.rect(ids[:mid], axis^1, rect, f) {
+		return false
+	}
+	return rect.Max[axis] < p[axis] || t.rect(ids[mid+1:], axis^1, rect, f)
+}
+
+// Calls `f` for each entry within `radius` of `center` until it returns false.
+func (t *KDTree[T]) Radius(center Vector2, radius f64, f func(id SpatialID, pos Vector2, value T) bool) {
+	t.radius(t.ids, 0, center, radius, f)
+}
+
+func (t *KDTree[T]) radius(ids []SpatialID, axis int, center Vector2, radius f64,
+	f func(id SpatialID, pos Vector2, value T) bool,
+) bool {
+	if len(ids) == 0 {
+		return true
+	}
+	mid := len(ids) / 2
+	id := ids[mid]
+	p := t.points[id]
+	if p.Sub(center).MagSq() <= radius*radius && !f(id, p, t.values[id]) {
+		return false
+	}
+	if center[axis]-radius <= p[axis] && !t.radius(ids[:mid], axis^1, center, radius, f) {
+		return false
+	}
+	return center[axis]+radius < p[axis] || t.radius(ids[mid+1:], axis^1, center, radius, f)
+}
+
+// Appends up to `k` entries closest to `center` to `dst`, nearest first.
+func (t *KDTree[T]) Nearest(center Vector2, k int, dst []SpatialID) []SpatialID {
+	if k <= 0 {
+		return dst
+	}
+	dstSqOf := func(id SpatialID) f64 {
+		return t.points[id].Sub(center).MagSq()
+	}
+	return t.nearest(t.ids, 0, center, len(dst), k, dst, dstSqOf)
+}
+
+func (t *KDTree[T]) nearest(ids []SpatialID, axis int, center Vector2, base, k int, best []SpatialID,
+	dstSqOf func(SpatialID) f64,
+) []SpatialID {
+	if len(ids) == 0 {
+		return best
+	}
+	mid := len(ids) / 2
+	id := ids[mid]
+	best = nearestPush(best, base, k, id, dstSqOf(id), dstSqOf)
+	// Side of the split holding `center` first, the other only if it can be closer
+	d := center[axis] - t.points[id][axis]
+	near, far := ids[:mid], ids[mid+1:]
+	if d >= 0 {
+		near, far = far, near
+	}
+	best = t.nearest(near, axis^1, center, base, k, best, dstSqOf)
+	if d*d < nearestBound(best, base, k, dstSqOf) {
+		best = t.nearest(far, axis^1, center, base, k, best, dstSqOf)
+	}
+	return best
+}
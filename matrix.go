@@ -0,0 +1,177 @@
+package gomisc
+
+// 2x2 linear transform, columns are the transformed x and y axes.
+type Mat2 [2]Vector2
+
+// Leaves vectors unchanged.
+func Mat2Identity() Mat2 {
+	return Mat2{Vec2(1, 0), Vec2(0, 1)}
+}
+
+// Rotates by `amount`.
+func Mat2Rotate(amount Rad) Mat2 {
+	x := amount.Vec2()
+	return Mat2{x, x.Rot90()}
+}
+
+// Scales each axis by `scale`.
+func Mat2Scale(scale Vector2) Mat2 {
+	return Mat2{Vec2(scale[0], 0), Vec2(0, scale[1])}
+}
+
+// Shifts x by y*`shear[0]` and y by x*`shear[1]`.
+func Mat2Shear(shear Vector2) Mat2 {
+	return Mat2{Vec2(1, shear[1]), Vec2(shear[0], 1)}
+}
+
+// `m` after `other`.
+func (m Mat2) Mul(other Mat2) Mat2 {
+	return Mat2{m.TransformDir(other[0]), m.TransformDir(other[1])}
+}
+
+// Transforms `v`.
+func (m Mat2) TransformDir(v Vector2) Vector2 {
+	return m[0].Mul1(v[0]).Add(m[1].Mul1(v[1]))
+}
+
+// Area scale factor, negative if mirrored.
+func (m Mat2) Determinant() f64 {
+	return m[0].Cross(m[1])
+}
+
+// Rows become columns.
+func (m Mat2) Transpose() Mat2 {
+	return Mat2{Vec2(m[0][0], m[1][0]), Vec2(m[0][1], m[1][1])}
+}
+
+// Undoes `m`, false if `m` is singular.
+func (m Mat2) Inverse() (Mat2, bool) {
+	det := m.Determinant()
+	if det == 0 {
+		return Mat2{}, false
+	}
+	return Mat2{
+		Vec2(m[1][1], -m[0][1]).Div1(det),
+		Vec2(-m[1][0], m[0][0]).Div1(det),
+	}, true
+}
+
+// 2D affine transform, a Mat2 followed by a translation.
+// Equivalent to a 3x3 matrix with (0, 0, 1) bottom row.
+type Mat3 struct {
+	Linear      Mat2
+	Translation Vector2
+}
+
+// Leaves points unchanged.
+func Mat3Identity() Mat3 {
+	return Mat3{Mat2Identity(), Vector2{}}
+}
+
+// Moves by `offset`.
+func Mat3Translate(offset Vector2) Mat3 {
+	return Mat3{Mat2Identity(), offset}
+}
+
+// Rotates around the origin by `amount`.
+func Mat3Rotate(amount Rad) Mat3 {
+	return Mat3{Mat2Rotate(amount), Vector2{}}
+}
+
+// Scales each axis by `scale` from the origin.
+func Mat3Scale(scale Vector2) Mat3 {
+	return Mat3{Mat2Scale(scale), Vector2{}}
+}
+
+// Shifts x by y*`shear[0]` and y by x*`shear[1]`.
+func Mat3Shear(shear Vector2) Mat3 {
+	return Mat3{Mat2Shear(shear), Vector2{}}
+}
+
+// Scales, then rotates, then translates.
+func Mat3TRS(translation Vector2, rotation Rad, scale Vector2) Mat3 {
+	return Mat3{Mat2Rotate(rotation).Mul(Mat2Scale(scale)), translation}
+}
+
+// `m` after `other`.
+func (m Mat3) Mul(other Mat3) Mat3 {
+	return Mat3{m.Linear.Mul(other.Linear), m.TransformPoint(other.Translation)}
+}
+
+// Transforms position `p`, affected by translation.
+func (m Mat3) TransformPoint(p Vector2) Vector2 {
+	return m.Linear.TransformDir(p).Add(m.Translation)
+}
+
+// Transforms direction `v`, unaffected by translation.
+func (m Mat3) TransformDir(v Vector2) Vector2 {
+	return m.Linear.TransformDir(v)
+}
+
+// Area scale factor, negative if mirrored.
+func (m Mat3) Determinant() f64 {
+	return m.Linear.Determinant()
+}
+
+// Undoes `m`, false if `m` is singular.
+func (m Mat3) Inverse() (Mat3, bool) {
+	linear, ok := m.Linear.Inverse()
+	if !ok {
+		return Mat3{}, false
+	}
+	return Mat3{linear, linear.TransformDir(m.Translation).Neg()}, true
+}
+
+// Splits into Mat3TRS parts, shear is lost.
+// Mirroring is expressed as negative y scale.
+func (m Mat3) Decompose() (translation Vector2, rotation Rad, scale Vector2) {
+	x, y := m.Linear[0], m.Linear[1]
+	scale = Vec2(x.Mag(), y.Mag())
+	if m.Determinant() < 0 {
+		scale[1] = -scale[1]
+	}
+	return m.Translation, x.Rad(), scale
+}
+
+// Position, rotation and scale relative to an optional parent.
+type Transform2D struct {
+	Position Vector2
+	Rotation Rad
+	Scale    Vector2
+	Parent   *Transform2D
+}
+
+// At the origin with no rotation, unit scale and no parent.
+func Transform2DNew() Transform2D {
+	return Transform2D{Scale: Vec2(1, 1)}
+}
+
+// Transform relative to the parent.
+func (t Transform2D) Local() Mat3 {
+	return Mat3TRS(t.Position, t.Rotation, t.Scale)
+}
+
+// Transform relative to the root, composing every parent.
+func (t Transform2D) World() Mat3 {
+	result := t.Local()
+	for parent := t.Parent; parent != nil; parent = parent.Parent {
+		result = parent.Local().Mul(result)
+	}
+	return result
+}
+
+// Local position `p` to root space.
+func (t Transform2D) ToWorld(p Vector2) Vector2 {
+	return t.World().TransformPoint(p)
+}
+
+// Root space position `p` to local space, false if scale is 0.
+func (t Transform2D) FromWorld(p Vector2) (Vector2, bool) {
+	inverse, ok := t.World().Inverse()
+	return inverse.TransformPoint(p), ok
+}
+
+// Position, rotation and scale relative to the root, shear is lost.
+func (t Transform2D) WorldTRS() (position Vector2, rotation Rad, scale Vector2) {
+	return t.World().Decompose()
+}
@@ -0,0 +1,477 @@
+package gomisc
+
+// Seeded coherent noise.
+// Results are in range [-1,1], Worley returns distances instead.
+type Noise u32
+
+// Initializes with seed
+func NoiseNew(seed u64) Noise {
+	return Noise(Hash64From(seed) >> 32)
+}
+
+// Initializes with SeedGen64
+func NoiseInit() Noise {
+	return NoiseNew(SeedGen64())
+}
+
+func (n Noise) hash1(x int) u32 {
+	return Hash32New(u32(n)).Mix(u32(x)).Result()
+}
+
+func (n Noise) hash2(x, y int) u32 {
+	return Hash32New(u32(n)).Mix(u32(x)).Mix(u32(y)).Result()
+}
+
+func (n Noise) hash3(x, y, z int) u32 {
+	return Hash32New(u32(n)).Mix(u32(x)).Mix(u32(y)).Mix(u32(z)).Result()
+}
+
+func (n Noise) hash4(x, y, z, w int) u32 {
+	return Hash32New(u32(n)).Mix(u32(x)).Mix(u32(y)).Mix(u32(z)).Mix(u32(w)).Result()
+}
+
+// Hash to range [-1,1].
+func hashSigned(h u32) f64 {
+	return f64(h>>8)/(1<<23) - 1
+}
+
+// Hash to range [0,1).
+func hashUnsigned(h u32) f64 {
+	return f64(h>>8) / (1 << 24)
+}
+
+// Unit gradients at 22.5 degree intervals
+var noiseGrad2 = func() (result [16]Vector2) {
+	for i := range result {
+		result[i] = Rad(Tau * (f64(i) + .5) / 16).Vec2()
+	}
+	return
+}()
+
+// OpenSimplex2 unit gradients at 15 degree intervals
+var noiseGradSimplex2 = func() (result [24]Vector2) {
+	for i := range result {
+		result[i] = Rad(Tau * (f64(i) + .5) / 24).Vec2()
+	}
+	return
+}()
+
+// Cube edge midpoints
+var noiseGrad3 = [16][3]f64{
+	{1, 1, 0}, {-1, 1, 0}, {1, -1, 0}, {-1, -1, 0},
+	{1, 0, 1}, {-1, 0, 1}, {1, 0, -1}, {-1, 0, -1},
+	{0, 1, 1}, {0, -1, 1}, {0, 1, -1}, {0, -1, -1},
+	{1, 1, 0}, {-1, 1, 0}, {0, -1, 1}, {0, -1, -1},
+}
+
+func grad2(h u32, x, y f64) f64 {
+	g := noiseGrad2[h>>28]
+	return g[0]*x + g[1]*y
+}
+
+func gradSimplex2(h u32, x, y f64) f64 {
+	g := noiseGradSimplex2[u64(h)*24>>32]
+	return g[0]*x + g[1]*y
+}
+
+func grad3(h u32, x, y, z f64) f64 {
+	g := noiseGrad3[h>>28]
+	return g[0]*x + g[1]*y + g[2]*z
+}
+
+// One axis zeroed, others ±1
+func grad4(h u32, x, y, z, w f64) f64 {
+	v := [4]f64{x, y, z, w}
+	v[h>>30] = 0
+	return BToS(h&1 == 0)*v[0] + BToS(h&2 == 0)*v[1] +
+		BToS(h&4 == 0)*v[2] + BToS(h&8 == 0)*v[3]
+}
+
+// 1 dimensional Perlin noise.
+func (n Noise) Perlin1(x f64) f64 {
+	xi := FloorI(x)
+	xf := x - f64(xi)
+	a := hashSigned(n.hash1(xi)) * xf
+	b := hashSigned(n.hash1(xi+1)) * (xf - 1)
+	return 2 * Lerp(a, b, FadeQuintic(xf))
+}
+
+// 2 dimensional Perlin noise.
+func (n Noise) Perlin2(p Vector2) f64 {
+	xi, yi := FloorI(p[0]), FloorI(p[1])
+	x, y := p[0]-f64(xi), p[1]-f64(yi)
+	u, v := FadeQuintic(x), FadeQuintic(y)
+	return Sqrt2 * Lerp(
+		Lerp(grad2(n.hash2(xi, yi), x, y), grad2(n.hash2(xi+1, yi), x-1, y), u),
+		Lerp(grad2(n.hash2(xi, yi+1), x, y-1), grad2(n.hash2(xi+1, yi+1), x-1, y-1), u),
+		v,
+	)
+}
+
+// 3 dimensional Perlin noise.
+func (n Noise) Perlin3(p Vector3) f64 {
+	xi, yi, zi := FloorI(p[0]), FloorI(p[1]), FloorI(p[2])
+	x, y, z := p[0]-f64(xi), p[1]-f64(yi), p[2]-f64(zi)
+	u, v, w := FadeQuintic(x), FadeQuintic(y), FadeQuintic(z)
+	corner := func(dx, dy, dz int) f64 {
+		return grad3(n.hash3(xi+dx, yi+dy, zi+dz), x-f64(dx), y-f64(dy), z-f64(dz))
+	}
+	return Clamp(Lerp(
+		Lerp(Lerp(corner(0, 0, 0), corner(1, 0, 0), u), Lerp(corner(0, 1, 0), corner(1, 1, 0), u), v),
+		Lerp(Lerp(corner(0, 0, 1), corner(1, 0, 1), u), Lerp(corner(0, 1, 1), corner(1, 1, 1), u), v),
+		w,
+	), -1, 1)
+}
+
+// 4 dimensional Perlin noise.
+func (n Noise) Perlin4(p Vector4) f64 {
+	xi, yi, zi, wi := FloorI(p[0]), FloorI(p[1]), FloorI(p[2]), FloorI(p[3])
+	x, y, z, w := p[0]-f64(xi), p[1]-f64(yi), p[2]-f64(zi), p[3]-f64(wi)
+	fade := [4]f64{FadeQuintic(x), FadeQuintic(y), FadeQuintic(z), FadeQuintic(w)}
+	var corners [16]f64
+	for c := range corners {
+		dx, dy, dz, dw := c&1, c>>1&1, c>>2&1, c>>3&1
+		corners[c] = grad4(n.hash4(xi+dx, yi+dy, zi+dz, wi+dw),
+			x-f64(dx), y-f64(dy), z-f64(dz), w-f64(dw))
+	}
+	return Clamp(collapseCorners(corners, fade)*2/3, -1, 1)
+}
+
+// Interpolates hypercube `corners` one axis at a time.
+func collapseCorners(corners [16]f64, t [4]f64) f64 {
+	for axis, size := 0, 16; axis < 4; axis, size = axis+1, size/2 {
+		for c := 0; c < size/2; c++ {
+			corners[c] = Lerp(corners[c*2], corners[c*2+1], t[axis])
+		}
+	}
+	return corners[0]
+}
+
+// Simplex lattice constants
+const (
+	simplexSkew2   = .36602540378443864676  // (sqrt(3) - 1) / 2
+	simplexUnskew2 = .21132486540518711775  // (3 - sqrt(3)) / 6
+	simplexSkew4   = -.13819660112501051518 // (1/sqrt(5) - 1) / 4
+	simplexUnskew4 = .30901699437494742410  // (sqrt(5) - 1) / 4
+)
+
+// 2 dimensional OpenSimplex2 noise, fewer directional artifacts than Perlin2.
+// Sums the 3 corners of the surrounding triangle, gradients are spaced 15 degrees apart.
+func (n Noise) Simplex2(p Vector2) f64 {
+	// Skew so the triangle lattice becomes a square one
+	s := p.Add1(p.Sum() * simplexSkew2)
+	xi, yi := FloorI(s[0]), FloorI(s[1])
+	xs, ys := s[0]-f64(xi), s[1]-f64(yi)
+	t := (xs + ys) * simplexUnskew2
+	x0, y0 := xs-t, ys-t
+	contribution := func(h u32, x, y f64) f64 {
+		a := .5 - x*x - y*y
+		if a <= 0 {
+			return 0
+		}
+		return a * a * a * a * gradSimplex2(h, x, y)
+	}
+	value := contribution(n.hash2(xi, yi), x0, y0) +
+		contribution(n.hash2(xi+1, yi+1), x0-1+2*simplexUnskew2, y0-1+2*simplexUnskew2)
+	// Middle corner of the triangle
+	if y0 > x0 {
+		value += contribution(n.hash2(xi, yi+1), x0+simplexUnskew2, y0-1+simplexUnskew2)
+	} else {
+		value += contribution(n.hash2(xi+1, yi), x0-1+simplexUnskew2, y0+simplexUnskew2)
+	}
+	return Clamp(value*99.2, -1, 1)
+}
+
+// 3 dimensional OpenSimplex2 noise, evaluated on two offset cubic lattices (BCC).
+func (n Noise) Simplex3(p Vector3) f64 {
+	// Rotate so the lattice's main diagonal points up
+	r := p.Sum() * 2 / 3
+	x, y, z := r-p[0], r-p[1], r-p[2]
+	value := 0.
+	for lattice := 0; lattice < 2; lattice++ {
+		seed := n ^ Noise(lattice*0x5bd1e995)
+		xi, yi, zi := RoundI(x), RoundI(y), RoundI(z)
+		dx, dy, dz := x-f64(xi), y-f64(yi), z-f64(zi)
+		contribution := func(hx, hy, hz int, dx, dy, dz f64) {
+			if a := .6 - dx*dx - dy*dy - dz*dz; a > 0 {
+				value += a * a * a * a * grad3(seed.hash3(hx, hy, hz), dx, dy, dz)
+			}
+		}
+		contribution(xi, yi, zi, dx, dy, dz)
+		// Second closest vertex lies along the largest offset axis
+		ax, ay, az := Abs(dx), Abs(dy), Abs(dz)
+		if ax >= ay && ax >= az {
+			sx := int(Sign(dx))
+			contribution(xi+sx, yi, zi, dx-f64(sx), dy, dz)
+		} else if ay >= az {
+			sy := int(Sign(dy))
+			contribution(xi, yi+sy, zi, dx, dy-f64(sy), dz)
+		} else {
+			sz := int(Sign(dz))
+			contribution(xi, yi, zi+sz, dx, dy, dz-f64(sz))
+		}
+		// Second lattice is offset by half a cell
+		x, y, z = x+.5, y+.5, z+.5
+	}
+	return Clamp(value*32, -1, 1)
+}
+
+// 4 dimensional OpenSimplex2 noise, evaluated on five hypercubic lattices
+// offset by a fifth of the main diagonal.
+func (n Noise) Simplex4(p Vector4) f64 {
+	// Skew so the lattices become hypercubic
+	s := p.Add1(p.Sum() * simplexSkew4)
+	var vertex [4]int
+	var d Vector4 // Offset from vertex in skewed space
+	for i := range vertex {
+		vertex[i] = FloorI(s[i])
+		d[i] = s[i] - f64(vertex[i])
+	}
+	// Start with the lattice whose vertices are closest
+	lattice := int(d.Sum() * 1.25)
+	d = d.Sub1(f64(lattice) * .2)
+	value := 0.
+	for i := 0; i < 5; i++ {
+		// Step along the largest offset axis if that vertex is closer
+		axis := 0
+		for a := 1; a < 4; a++ {
+			if d[a] > d[axis] {
+				axis = a
+			}
+		}
+		if d[axis] >= 1-d.Sum() {
+			vertex[axis]++
+			d[axis]--
+		}
+		offset := d.Add1(d.Sum() * simplexUnskew4)
+		if a := .6 - offset.MagSq(); a > 0 {
+			h := (n ^ Noise(lattice*0x5bd1e995)).hash4(vertex[0], vertex[1], vertex[2], vertex[3])
+			value += a * a * a * a * grad4(h, offset[0], offset[1], offset[2], offset[3])
+		}
+		// Next lattice is a fifth of the diagonal lower, wrapping to the last one
+		d = d.Add1(.2)
+		if lattice--; lattice < 0 {
+			lattice = 4
+			for a := range vertex {
+				vertex[a]--
+			}
+		}
+	}
+	return Clamp(value*27, -1, 1)
+}
+
+// 1 dimensional value noise.
+func (n Noise) Value1(x f64) f64 {
+	xi := FloorI(x)
+	return Lerp(hashSigned(n.hash1(xi)), hashSigned(n.hash1(xi+1)),
+		FadeCubic(x-f64(xi)))
+}
+
+// 2 dimensional value noise.
+func (n Noise) Value2(p Vector2) f64 {
+	xi, yi := FloorI(p[0]), FloorI(p[1])
+	u, v := FadeCubic(p[0]-f64(xi)), FadeCubic(p[1]-f64(yi))
+	return Lerp(
+		Lerp(hashSigned(n.hash2(xi, yi)), hashSigned(n.hash2(xi+1, yi)), u),
+		Lerp(hashSigned(n.hash2(xi, yi+1)), hashSigned(n.hash2(xi+1, yi+1)), u),
+		v,
+	)
+}
+
+// 3 dimensional value noise.
+func (n Noise) Value3(p Vector3) f64 {
+	xi, yi, zi := FloorI(p[0]), FloorI(p[1]), FloorI(p[2])
+	u, v, w := FadeCubic(p[0]-f64(xi)), FadeCubic(p[1]-f64(yi)), FadeCubic(p[2]-f64(zi))
+	corner := func(dx, dy, dz int) f64 {
+		return hashSigned(n.hash3(xi+dx, yi+dy, zi+dz))
+	}
+	return Lerp(
+		Lerp(Lerp(corner(0, 0, 0), corner(1, 0, 0), u), Lerp(corner(0, 1, 0), corner(1, 1, 0), u), v),
+		Lerp(Lerp(corner(0, 0, 1), corner(1, 0, 1), u), Lerp(corner(0, 1, 1), corner(1, 1, 1), u), v),
+		w,
+	)
+}
+
+// 4 dimensional value noise.
+func (n Noise) Value4(p Vector4) f64 {
+	xi, yi, zi, wi := FloorI(p[0]), FloorI(p[1]), FloorI(p[2]), FloorI(p[3])
+	fade := [4]f64{FadeCubic(p[0] - f64(xi)), FadeCubic(p[1] - f64(yi)),
+		FadeCubic(p[2] - f64(zi)), FadeCubic(p[3] - f64(wi))}
+	var corners [16]f64
+	for c := range corners {
+		corners[c] = hashSigned(n.hash4(xi+c&1, yi+c>>1&1, zi+c>>2&1, wi+c>>3&1))
+	}
+	return collapseCorners(corners, fade)
+}
+
+// 2 dimensional Worley (cellular) noise.
+// Distances to the closest and second closest feature points, one per cell.
+func (n Noise) Worley2(p Vector2) (f1, f2 f64) {
+	xi, yi := FloorI(p[0]), FloorI(p[1])
+	f1, f2 = 2, 2
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			cx, cy := xi+dx, yi+dy
+			h := n.hash2(cx, cy)
+			point := Vec2(f64(cx)+hashUnsigned(h), f64(cy)+hashUnsigned(Hash32New(h).Result()))
+			if d := point.Dst(p); d < f1 {
+				f1, f2 = d, f1
+			} else if d < f2 {
+				f2 = d
+			}
+		}
+	}
+	return f1, f2
+}
+
+// 3 dimensional Worley (cellular) noise.
+// Distances to the closest and second closest feature points, one per cell.
+func (n Noise) Worley3(p Vector3) (f1, f2 f64) {
+	xi, yi, zi := FloorI(p[0]), FloorI(p[1]), FloorI(p[2])
+	f1, f2 = 2, 2
+	for dz := -1; dz <= 1; dz++ {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				cx, cy, cz := xi+dx, yi+dy, zi+dz
+				h := Hash32New(n.hash3(cx, cy, cz))
+				px := f64(cx) + hashUnsigned(h.Result())
+				h = h.Mix(0)
+				py := f64(cy) + hashUnsigned(h.Result())
+				h = h.Mix(0)
+				pz := f64(cz) + hashUnsigned(h.Result())
+				if d := Vec3(px, py, pz).Dst(p); d < f1 {
+					f1, f2 = d, f1
+				} else if d < f2 {
+					f2 = d
+				}
+			}
+		}
+	}
+	return f1, f2
+}
+
+// 4 dimensional Worley (cellular) noise.
+// Distances to the closest and second closest feature points, one per cell.
+func (n Noise) Worley4(p Vector4) (f1, f2 f64) {
+	var base [4]int
+	for i := range base {
+		base[i] = FloorI(p[i])
+	}
+	f1, f2 = 3, 3
+	for c := 0; c < 81; c++ {
+		cell := [4]int{base[0] + c%3 - 1, base[1] + c/3%3 - 1, base[2] + c/9%3 - 1, base[3] + c/27 - 1}
+		h := Hash32New(n.hash4(cell[0], cell[1], cell[2], cell[3]))
+		var point Vector4
+		for i := range point {
+			point[i] = f64(cell[i]) + hashUnsigned(h.Result())
+			h = h.Mix(0)
+		}
+		if d := point.Dst(p); d < f1 {
+			f1, f2 = d, f1
+		} else if d < f2 {
+			f2 = d
+		}
+	}
+	return f1, f2
+}
+
+// Layering of noise octaves.
+type Fractal struct {
+	Octaves    int
+	Lacunarity f64 // Frequency multiplier per octave
+	Gain       f64 // Amplitude multiplier per octave
+}
+
+// 5 octaves, each double the frequency and half the amplitude.
+func FractalNew() Fractal {
+	return Fractal{5, 2, .5}
+}
+
+func (f Fractal) sum(sample func(frequency f64) f64) f64 {
+	PanicIf(f.Octaves < 1, "Fractal needs at least 1 octave")
+	result, amplitude, frequency, total := 0., 1., 1., 0.
+	for i := 0; i < f.Octaves; i++ {
+		result += sample(frequency) * amplitude
+		total += amplitude
+		frequency *= f.Lacunarity
+		amplitude *= f.Gain
+	}
+	return result / total
+}
+
+// Fractal Brownian motion, in range [-1,1].
+func (f Fractal) FBm2(noise func(Vector2) f64, p Vector2) f64 {
+	return f.sum(func(frequency f64) f64 { return noise(p.Mul1(frequency)) })
+}
+
+// Fractal Brownian motion, in range [-1,1].
+func (f Fractal) FBm3(noise func(Vector3) f64, p Vector3) f64 {
+	return f.sum(func(frequency f64) f64 { return noise(p.Mul1(frequency)) })
+}
+
+// Fractal Brownian motion, in range [-1,1].
+func (f Fractal) FBm4(noise func(Vector4) f64, p Vector4) f64 {
+	return f.sum(func(frequency f64) f64 { return noise(p.Mul1(frequency)) })
+}
+
+// Sharp ridges where noise crosses 0, in range [0,1].
+func (f Fractal) Ridged2(noise func(Vector2) f64, p Vector2) f64 {
+	return f.sum(func(frequency f64) f64 { return ridge(noise(p.Mul1(frequency))) })
+}
+
+// Sharp ridges where noise crosses 0, in range [0,1].
+func (f Fractal) Ridged3(noise func(Vector3) f64, p Vector3) f64 {
+	return f.sum(func(frequency f64) f64 { return ridge(noise(p.Mul1(frequency))) })
+}
+
+// Sharp ridges where noise crosses 0, in range [0,1].
+func (f Fractal) Ridged4(noise func(Vector4) f64, p Vector4) f64 {
+	return f.sum(func(frequency f64) f64 { return ridge(noise(p.Mul1(frequency))) })
+}
+
+func ridge(value f64) f64 {
+	r := 1 - Abs(value)
+	return r * r
+}
+
+// Creases where noise crosses 0, in range [0,1].
+func (f Fractal) Turbulence2(noise func(Vector2) f64, p Vector2) f64 {
+	return f.sum(func(frequency f64) f64 { return Abs(noise(p.Mul1(frequency))) })
+}
+
+// Creases where noise crosses 0, in range [0,1].
+func (f Fractal) Turbulence3(noise func(Vector3) f64, p Vector3) f64 {
+	return f.sum(func(frequency f64) f64 { return Abs(noise(p.Mul1(frequency))) })
+}
+
+// Creases where noise crosses 0, in range [0,1].
+func (f Fractal) Turbulence4(noise func(Vector4) f64, p Vector4) f64 {
+	return f.sum(func(frequency f64) f64 { return Abs(noise(p.Mul1(frequency))) })
+}
+
+// Offsets `p` by `noise` sampled at 2 decorrelated positions, scaled by `amount`.
+// Sampling noise at the result produces warped patterns.
+func DomainWarp2(noise func(Vector2) f64, p Vector2, amount f64) Vector2 {
+	return p.Add(Vec2(noise(p), noise(p.Add(Vec2(5.2, 1.3)))).Mul1(amount))
+}
+
+// Offsets `p` by `noise` sampled at 3 decorrelated positions, scaled by `amount`.
+// Sampling noise at the result produces warped patterns.
+func DomainWarp3(noise func(Vector3) f64, p Vector3, amount f64) Vector3 {
+	return p.Add(Vec3(
+		noise(p),
+		noise(p.Add(Vec3(5.2, 1.3, 2.8))),
+		noise(p.Add(Vec3(1.7, 9.2, 4.1))),
+	).Mul1(amount))
+}
+
+// Offsets `p` by `noise` sampled at 4 decorrelated positions, scaled by `amount`.
+// Sampling noise at the result produces warped patterns.
+func DomainWarp4(noise func(Vector4) f64, p Vector4, amount f64) Vector4 {
+	return p.Add(Vec4(
+		noise(p),
+		noise(p.Add(Vec4(5.2, 1.3, 2.8, 7.4))),
+		noise(p.Add(Vec4(1.7, 9.2, 4.1, 3.6))),
+		noise(p.Add(Vec4(8.3, 2.9, 6.5, 0.4))),
+	).Mul1(amount))
+}
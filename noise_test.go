@@ -0,0 +1,80 @@
+package gomisc
+
+import "testing"
+
+// Noise functions of every dimension, sampled at a 4D point.
+func noiseSamplers(n Noise) map[string]func(Vector4) f64 {
+	return map[string]func(Vector4) f64{
+		"Perlin1":  func(p Vector4) f64 { return n.Perlin1(p[0]) },
+		"Perlin2":  func(p Vector4) f64 { return n.Perlin2(Vec2(p[0], p[1])) },
+		"Perlin3":  func(p Vector4) f64 { return n.Perlin3(Vec3(p[0], p[1], p[2])) },
+		"Perlin4":  n.Perlin4,
+		"Simplex2": func(p Vector4) f64 { return n.Simplex2(Vec2(p[0], p[1])) },
+		"Simplex3": func(p Vector4) f64 { return n.Simplex3(Vec3(p[0], p[1], p[2])) },
+		"Simplex4": n.Simplex4,
+		"Value1":   func(p Vector4) f64 { return n.Value1(p[0]) },
+		"Value2":   func(p Vector4) f64 { return n.Value2(Vec2(p[0], p[1])) },
+		"Value3":   func(p Vector4) f64 { return n.Value3(Vec3(p[0], p[1], p[2])) },
+		"Value4":   n.Value4,
+	}
+}
+
+// Values stay in [-1,1], use most of it and change little over a short step.
+func TestNoiseRange(t *testing.T) {
+	for name, noise := range noiseSamplers(NoiseNew(1)) {
+		t.Run(name, func(t *testing.T) {
+			s := PCG64New(1)
+			low, high, jump := 0., 0., 0.
+			for i := 0; i < 20000; i++ {
+				var p, step Vector4
+				for a := range p {
+					p[a] = RandFloat64(&s)*100 - 50
+					step[a] = (RandFloat64(&s) - .5) * 1e-6
+				}
+				v := noise(p)
+				low, high = Min(low, v), Max(high, v)
+				jump = Max(jump, Abs(noise(p.Add(step))-v))
+			}
+			if low < -1 || high > 1 || high-low < 1 {
+				t.Errorf("range = [%v,%v]", low, high)
+			}
+			if jump > 1e-4 {
+				t.Errorf("jump over a short step = %v", jump)
+			}
+		})
+	}
+}
+
+// The feature point of the containing cell is at most a cell diagonal away.
+func TestWorley(t *testing.T) {
+	n := NoiseNew(1)
+	s := PCG64New(1)
+	for i := 0; i < 2000; i++ {
+		p := Vec4(RandFloat64(&s)*100, RandFloat64(&s)*100, RandFloat64(&s)*100, RandFloat64(&s)*100)
+		for dim, f := range [][2]f64{
+			func() (r [2]f64) { r[0], r[1] = n.Worley2(Vec2(p[0], p[1])); return }(),
+			func() (r [2]f64) { r[0], r[1] = n.Worley3(Vec3(p[0], p[1], p[2])); return }(),
+			func() (r [2]f64) { r[0], r[1] = n.Worley4(p); return }(),
+		} {
+			if f[0] < 0 || f[0] > f[1] || f[0] > Sqrt(f64(dim+2)) {
+				t.Fatalf("Worley%d(%v) = %v, %v", dim+2, p, f[0], f[1])
+			}
+		}
+	}
+}
+
+func TestFractalRange(t *testing.T) {
+	n := NoiseNew(1)
+	f := FractalNew()
+	p := Vec4(1.3, 2.7, 3.1, 4.9)
+	if v := f.FBm4(n.Simplex4, p); v < -1 || v > 1 {
+		t.Errorf("FBm4 = %v", v)
+	}
+	if v := f.Ridged3(n.Simplex3, Vec3(p[0], p[1], p[2])); v < 0 || v > 1 {
+		t.Errorf("Ridged3 = %v", v)
+	}
+	if v := f.Turbulence4(n.Perlin4, p); v < 0 || v > 1 {
+		t.Errorf("Turbulence4 = %v", v)
+	}
+	testPanics(t, "Fractal without octaves", func() { Fractal{}.FBm2(n.Simplex2, Vec2(1, 2)) })
+}
@@ -0,0 +1,358 @@
+package gomisc
+
+import "sort"
+
+// Smallest convex polygon containing `points`, counter-clockwise (Andrew's monotone chain).
+// Duplicate and collinear points are dropped, all collinear points give the 2 endpoints.
+func ConvexHull(points []Vector2) Polygon {
+	sorted := append([]Vector2(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i][0] < sorted[j][0] || sorted[i][0] == sorted[j][0] && sorted[i][1] < sorted[j][1]
+	})
+	unique := sorted[:0]
+	for i, p := range sorted {
+		if i == 0 || !p.Eq(unique[len(unique)-1]) {
+			unique = append(unique, p)
+		}
+	}
+	if len(unique) < 3 {
+		return Polygon(unique)
+	}
+	result := make(Polygon, 0, len(unique)+1)
+	// Lower chain left to right, then upper chain right to left
+	for pass := 0; pass < 2; pass++ {
+		start := len(result)
+		for _, p := range unique {
+			for len(result) >= start+2 &&
+				result[len(result)-1].Sub(result[len(result)-2]).Cross(p.Sub(result[len(result)-1])) <= 0 {
+				result = result[:len(result)-1]
+			}
+			result = append(result, p)
+		}
+		// Last point starts the other chain
+		result = result[:len(result)-1]
+		for i, j := 0, len(unique)-1; i < j; i, j = i+1, j-1 {
+			unique[i], unique[j] = unique[j], unique[i]
+		}
+	}
+	return result
+}
+
+// Positive if `p` is counter-clockwise.
+func (p Polygon) SignedArea() f64 {
+	return signedArea(p)
+}
+
+// Area of `p`.
+func (p Polygon) Area() f64 {
+	return Abs(signedArea(p))
+}
+
+// Is `p` wound clockwise.
+func (p Polygon) Clockwise() bool {
+	return signedArea(p) < 0
+}
+
+// `p` with the opposite winding.
+func (p Polygon) Reverse() Polygon {
+	result := make(Polygon, len(p))
+	for i, v := range p {
+		result[len(p)-1-i] = v
+	}
+	return result
+}
+
+// Center of mass of `p`, the vertex mean if it has no area.
+func (p Polygon) Centroid() Vector2 {
+	PanicIf(len(p) == 0, "Empty polygon has no centroid")
+	area := signedArea(p)
+	if area == 0 {
+		return vertexMean(p)
+	}
+	// Relative to the first vertex for precision far from the origin
+	result := Vector2{}
+	for i := range p {
+		a, b := p[i].Sub(p[0]), p[(i+1)%len(p)].Sub(p[0])
+		result = result.Add(a.Add(b).Mul1(a.Cross(b)))
+	}
+	return result.Div1(6 * area).Add(p[0])
+}
+
+// Ear clipping triangulation, skipping zero area triangles.
+// Triangles are counter-clockwise regardless of `p` winding.
+func (p Polygon) Triangulate() []Triangle {
+	return triangulate(p)
+}
+
+// `p` with fewer vertices, none of the removed further than `epsilon` from the outline.
+func (p Polygon) Simplify(epsilon f64) Polygon {
+	if len(p) <= 3 {
+		return append(Polygon(nil), p...)
+	}
+	// Split the ring at the vertex furthest from the first
+	far := 0
+	for i, v := range p {
+		if v.Dst(p[0]) > p[far].Dst(p[0]) {
+			far = i
+		}
+	}
+	if far == 0 {
+		return Polygon{p[0]}
+	}
+	first := Simplify(p[:far+1], epsilon)
+	second := Simplify(append(append([]Vector2(nil), p[far:]...), p[0]), epsilon)
+	return append(Polygon(first), second[1:len(second)-1]...)
+}
+
+// `polyline` with fewer vertices, none of the removed further than `epsilon` from the result
+// (Ramer-Douglas-Peucker). Endpoints are kept.
+func Simplify(polyline []Vector2, epsilon f64) []Vector2 {
+	if len(polyline) <= 2 {
+		return append([]Vector2(nil), polyline...)
+	}
+	keep := make([]bool, len(polyline))
+	keep[0], keep[len(polyline)-1] = true, true
+	stack := [][2]int{{0, len(polyline) - 1}}
+	for len(stack) > 0 {
+		span := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		edge := Segment{polyline[span[0]], polyline[span[1]]}
+		far, farDst := -1, epsilon
+		for i := span[0] + 1; i < span[1]; i++ {
+			if d := edge.Dst(polyline[i]); d > farDst {
+				far, farDst = i, d
+			}
+		}
+		if far >= 0 {
+			keep[far] = true
+			stack = append(stack, [2]int{span[0], far}, [2]int{far, span[1]})
+		}
+	}
+	var result []Vector2
+	for i, v := range polyline {
+		if keep[i] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// `p` grown outward by `distance`, shrunk if negative, in either winding.
+// Corners sharper than the miter limit are beveled.
+// Self intersections from shrinking concave parts are not resolved.
+func (p Polygon) Offset(distance f64) Polygon {
+	// Repeated vertices have no edge direction
+	unique := make(Polygon, 0, len(p))
+	for i, v := range p {
+		if !v.Eq(p[(i+1)%len(p)]) {
+			unique = append(unique, v)
+		}
+	}
+	n := len(unique)
+	if n < 3 {
+		return unique
+	}
+	side := 1.
+	if signedArea(unique) < 0 {
+		side = -1
+	}
+	// Outward normal of the edge starting at vertex `i`
+	normal := func(i int) Vector2 {
+		return unique[i].Sub(unique[(i+1)%n]).Rot90().Norm().Mul1(side)
+	}
+	result := make(Polygon, 0, n)
+	for i, v := range unique {
+		n0, n1 := normal((i+n-1)%n), normal(i)
+		miter := n0.Add(n1)
+		// Length of the miter relative to `distance` is 1/cos(half the turn)
+		cos := miter.Mag() / 2
+		if cos < offsetMiterLimit {
+			result = append(result, v.Add(n0.Mul1(distance)), v.Add(n1.Mul1(distance)))
+			continue
+		}
+		result = append(result, v.Add(miter.Norm().Mul1(distance/cos)))
+	}
+	return result
+}
+
+// Corners whose miter would exceed 4 times the offset distance are beveled.
+const offsetMiterLimit = .25
+
+// Delaunay triangulation of `points`, counter-clockwise (Bowyer-Watson).
+// Duplicate points are ignored, all collinear points give no triangles.
+func Delaunay(points []Vector2) []Triangle {
+	indices := delaunay(points)
+	result := make([]Triangle, len(indices))
+	for i, t := range indices {
+		result[i] = Triangle{points[t[0]], points[t[1]], points[t[2]]}
+	}
+	return result
+}
+
+// Delaunay triangles as counter-clockwise indices into `points`.
+// Of duplicate points only the first is used.
+func delaunay(points []Vector2) [][3]int {
+	if len(points) < 3 {
+		return nil
+	}
+	center := Polygon(points).Bounds().Center()
+	// Enclosing triangle at indices n, n+1, n+2, infinitely far so no hull triangle is cut
+	n := len(points)
+	triangles := [][3]int{{n, n + 1, n + 2}}
+	seen := make(map[Vector2]bool, n)
+	var edges [][2]int
+	for i, p := range points {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		// Remove triangles whose circumcircle contains `p`, keeping their outer edges
+		edges = edges[:0]
+		kept := triangles[:0]
+		for _, t := range triangles {
+			if !delaunayInCircle(points, center, t, p) {
+				kept = append(kept, t)
+				continue
+			}
+			for k := 0; k < 3; k++ {
+				edges = append(edges, [2]int{t[k], t[(k+1)%3]})
+			}
+		}
+		triangles = kept
+		// Connect `p` to the edges bordering the hole
+		for a, e := range edges {
+			shared := false
+			for b, other := range edges {
+				if a != b && e[0] == other[1] && e[1] == other[0] {
+					shared = true
+					break
+				}
+			}
+			if !shared {
+				triangles = append(triangles, [3]int{e[0], e[1], i})
+			}
+		}
+	}
+	result := triangles[:0]
+	for _, t := range triangles {
+		if t[0] >= n || t[1] >= n || t[2] >= n {
+			continue
+		}
+		// Collinear points on the hole border leave flat triangles
+		if points[t[1]].Sub(points[t[0]]).Cross(points[t[2]].Sub(points[t[0]])) > 0 {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// Counter-clockwise directions of the enclosing triangle vertices around `center`.
+// Equal lengths put the bisector of any 2 through `center`.
+var delaunaySuper = [3]Vector2{{0, 5}, {-4, -3}, {4, -3}}
+
+// Is `p` strictly inside the circumcircle of counter-clockwise triangle `t`.
+// Indices from len(`points`) are enclosing vertices infinitely far along delaunaySuper.
+func delaunayInCircle(points []Vector2, center Vector2, t [3]int, p Vector2) bool {
+	n := len(points)
+	far := BToI(t[0] >= n) + BToI(t[1] >= n) + BToI(t[2] >= n)
+	switch far {
+	case 0:
+		return inCircumcircle(points[t[0]], points[t[1]], points[t[2]], p)
+	case 3:
+		return true
+	}
+	// Rotate to (a, b, far) or (a, far, far)
+	for far == 1 && t[2] < n || far == 2 && t[0] >= n {
+		t = [3]int{t[1], t[2], t[0]}
+	}
+	a := points[t[0]]
+	if far == 1 {
+		// Circle through `a`, `b` and a far vertex is the half-plane left of `ab`
+		b := points[t[1]]
+		if side := b.Sub(a).Cross(p.Sub(a)); side != 0 {
+			return side > 0
+		}
+		return p.Sub(a).Dot(p.Sub(b)) < 0
+	}
+	// Circle through `a` and 2 far vertices is the half-plane toward them,
+	// bounded by the line through `a` parallel to theirs
+	u := delaunaySuper[t[2]-n].Sub(delaunaySuper[t[1]-n])
+	if side := u.Cross(p.Sub(a)); side != 0 {
+		return side < 0
+	}
+	// On that line, inside is nearer their bisector
+	return Abs(p.Sub(center).Dot(u)) < Abs(a.Sub(center).Dot(u))
+}
+
+// Is `p` strictly inside the circumcircle of counter-clockwise `a`, `b`, `c`.
+func inCircumcircle(a, b, c, p Vector2) bool {
+	a, b, c = a.Sub(p), b.Sub(p), c.Sub(p)
+	return a.MagSq()*b.Cross(c)+b.MagSq()*c.Cross(a)+c.MagSq()*a.Cross(b) > 0
+}
+
+// Voronoi cell of each point in `points`, clipped to `bounds`.
+// Duplicate points share a cell, points outside `bounds` may have an empty cell.
+func Voronoi(points []Vector2, bounds Rect) []Polygon {
+	first := make(map[Vector2]int, len(points))
+	for i, p := range points {
+		if _, ok := first[p]; !ok {
+			first[p] = i
+		}
+	}
+	// Cells are bounded by Delaunay neighbors alone
+	neighbors := make([][]int, len(points))
+	link := func(a, b int) {
+		for _, n := range neighbors[a] {
+			if n == b {
+				return
+			}
+		}
+		neighbors[a] = append(neighbors[a], b)
+	}
+	triangles := delaunay(points)
+	for _, t := range triangles {
+		for k := 0; k < 3; k++ {
+			link(t[k], t[(k+1)%3])
+			link(t[(k+1)%3], t[k])
+		}
+	}
+	if len(triangles) == 0 {
+		// Collinear, every distinct point may border every other
+		for _, a := range first {
+			for _, b := range first {
+				if a != b {
+					neighbors[a] = append(neighbors[a], b)
+				}
+			}
+		}
+	}
+	result := make([]Polygon, len(points))
+	for i, p := range points {
+		if j := first[p]; j != i {
+			result[i] = result[j]
+			continue
+		}
+		cell := bounds.Polygon()
+		for _, j := range neighbors[i] {
+			cell = clipHalfPlane(cell, p.Lerp(points[j], .5), points[j].Sub(p))
+		}
+		result[i] = cell
+	}
+	return result
+}
+
+// Part of convex `polygon` on the side of `point` opposite to `normal` (Sutherland-Hodgman).
+func clipHalfPlane(polygon Polygon, point, normal Vector2) Polygon {
+	result := make(Polygon, 0, len(polygon)+1)
+	for i, a := range polygon {
+		b := polygon[(i+1)%len(polygon)]
+		da, db := a.Sub(point).Dot(normal), b.Sub(point).Dot(normal)
+		if da <= 0 {
+			result = append(result, a)
+		}
+		if (da < 0 && db > 0) || (da > 0 && db < 0) {
+			result = append(result, a.Lerp(b, da/(da-db)))
+		}
+	}
+	return result
+}
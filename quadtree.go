@@ -0,0 +1,222 @@
+package gomisc
+
+// Entries a leaf holds before splitting.
+const quadtreeCapacity = 8
+
+// Splits stop at this depth, so coincident entries can't recurse forever.
+const quadtreeMaxDepth = 16
+
+// Quadtree whose nodes reach past their quadrant by half its size, so entries
+// moving a little stay in their node. Adapts to uneven density.
+type LooseQuadtree[T any] struct {
+	store spatialStore[T, int]
+	nodes []quadtreeNode
+}
+
+type quadtreeNode struct {
+	// Quadrant of the parent, and the loose area all entries below lie in
+	tight, loose Rect
+	// Index of the first of 4 children, 0 for a leaf
+	children int
+	depth    int
+	ids      []SpatialID
+}
+
+// Empty tree over `bounds`.
+// Entries outside `bounds` are kept in the root and checked by every query.
+func LooseQuadtreeNew[T any](bounds Rect) LooseQuadtree[T] {
+	return LooseQuadtree[T]{nodes: []quadtreeNode{{tight: bounds, loose: bounds}}}
+}
+
+// Entries in `q`.
+func (q *LooseQuadtree[T]) Len() int {
+	return q.store.len
+}
+
+// Deepest node that should hold `p`, starting at `node`.
+func (q *LooseQuadtree[T]) nodeFor(node int, p Vector2) int {
+	if node == 0 && !q.nodes[0].tight.Contains(p) {
+		return 0
+	}
+	for q.nodes[node].children != 0 {
+		node = q.nodes[node].children + q.quadrant(node, p)
+	}
+	return node
+}
+
+// Child index of `node` whose quadrant `p` falls in.
+func (q *LooseQuadtree[T]) quadrant(node int, p Vector2) int {
+	center := q.nodes[node].tight.Center()
+	result := 0
+	if p[0] >= center[0] {
+		result |= 1
+	}
+	if p[1] >= center[1] {
+		result |= 2
+	}
+	return result
+}
+
+func (q *LooseQuadtree[T]) link(id SpatialID, node int) {
+	n := &q.nodes[node]
+	n.ids = append(n.ids, id)
+	q.store.entries[id].location = node
+	if len(n.ids) > quadtreeCapacity && n.children == 0 && n.depth < quadtreeMaxDepth {
+		q.split(node)
+	}
+}
+
+func (q *LooseQuadtree[T]) unlink(id SpatialID, node int) {
+	ids := q.nodes[node].ids
+	for i, other := range ids {
+		if other == id {
+			ids[i] = ids[len(ids)-1]
+			q.nodes[node].ids = ids[:len(ids)-1]
+			return
+		}
+	}
+}
+
+// Gives `node` 4 children and moves its entries down where they fit.
+func (q *LooseQuadtree[T]) split(node int) {
+	tight := q.nodes[node].tight
+	half := tight.Size().Mul1(.5)
+	first := len(q.nodes)
+	for i := 0; i < 4; i++ {
+		min := tight.Min.Add(Vec2(f64(i&1), f64(i>>1)).Mul(half))
+		child := Rect{min, min.Add(half)}
+		q.nodes = append(q.nodes, quadtreeNode{
+			tight: child,
+			loose: child.Expand(half.Max() / 2),
+			depth: q.nodes[node].depth + 1,
+		})
+	}
+	q.nodes[node].children = first
+	ids := q.nodes[node].ids
+	kept := ids[:0]
+	for _, id := range ids {
+		pos := q.store.entries[id].pos
+		child := first + q.quadrant(node, pos)
+		if node == 0 && !q.nodes[0].tight.Contains(pos) || !q.nodes[child].loose.Contains(pos) {
+			kept = append(kept, id)
+			continue
+		}
+		q.link(id, child)
+	}
+	q.nodes[node].ids = kept
+}
+
+// Adds `value` at `pos`.
+func (q *LooseQuadtree[T]) Insert(pos Vector2, value T) SpatialID {
+	id := q.store.add(pos, value, 0)
+	q.link(id, q.nodeFor(0, pos))
+	return id
+}
+
+// Removes entry `id`, the ID may be reused.
+func (q *LooseQuadtree[T]) Remove(id SpatialID) {
+	q.unlink(id, q.store.at(id).location)
+	q.store.remove(id)
+}
+
+// Changes the position of entry `id`.
+// Stays in place while inside the loose area of its node.
+func (q *LooseQuadtree[T]) Move(id SpatialID, pos Vector2) {
+	entry := q.store.at(id)
+	entry.pos = pos
+	node := entry.location
+	if node != 0 && q.nodes[node].loose.Contains(pos) {
+		return
+	}
+	q.unlink(id, node)
+	q.link(id, q.nodeFor(0, pos))
+}
+
+// Position and value of entry `id`.
+func (q *LooseQuadtree[T]) Get(id SpatialID) (Vector2, T) {
+	entry := q.store.at(id)
+	return entry.pos, entry.value
+}
+
+// Calls `f` for each entry in `rect` until it returns false.
+func (q *LooseQuadtree[T]) Rect(rect Rect, f func(id SpatialID, pos Vector2, value T) bool) {
+	q.query(0, func(loose Rect) bool { return loose.Overlaps(rect) }, func(pos Vector2) bool {
+		return rect.Contains(pos)
+	}, f)
+}
+
+// Calls `f` for each entry within `radius` of `center` until it returns false.
+func (q *LooseQuadtree[T]) Radius(center Vector2, radius f64, f func(id SpatialID, pos Vector2, value T) bool) {
+	rSq := radius * radius
+	q.query(0, func(loose Rect) bool {
+		return loose.ClosestPoint(center).Sub(center).MagSq() <= rSq
+	}, func(pos Vector2) bool {
+		return pos.Sub(center).MagSq() <= rSq
+	}, f)
+}
+
+// Visits nodes whose loose area passes `enter`, false once `f` returns false.
+// The root is always entered, it may hold entries outside its bounds.
+func (q *LooseQuadtree[T]) query(node int, enter func(Rect) bool, accept func(Vector2) bool,
+	f func(id SpatialID, pos Vector2, value T) bool,
+) bool {
+	n := &q.nodes[node]
+	if node != 0 && !enter(n.loose) {
+		return true
+	}
+	for _, id := range n.ids {
+		entry := &q.store.entries[id]
+		if accept(entry.pos) && !f(id, entry.pos, entry.value) {
+			return false
+		}
+	}
+	if n.children != 0 {
+		for i := 0; i < 4; i++ {
+			if !q.query(n.children+i, enter, accept, f) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Appends up to `k` entries closest to `center` to `dst`, nearest first.
+func (q *LooseQuadtree[T]) Nearest(center Vector2, k int, dst []SpatialID) []SpatialID {
+	if k <= 0 {
+		return dst
+	}
+	dstSqOf := func(id SpatialID) f64 {
+		return q.store.entries[id].pos.Sub(center).MagSq()
+	}
+	return q.nearest(0, center, len(dst), k, dst, dstSqOf)
+}
+
+func (q *LooseQuadtree[T]) nearest(node int, center Vector2, base, k int, best []SpatialID,
+	dstSqOf func(SpatialID) f64,
+) []SpatialID {
+	n := &q.nodes[node]
+	for _, id := range n.ids {
+		best = nearestPush(best, base, k, id, dstSqOf(id), dstSqOf)
+	}
+	if n.children == 0 {
+		return best
+	}
+	// Closer children first so the bound tightens sooner
+	var order [4]int
+	var dsts [4]f64
+	for i := range order {
+		order[i] = n.children + i
+		dsts[i] = q.nodes[order[i]].loose.ClosestPoint(center).Sub(center).MagSq()
+		for j := i; j > 0 && dsts[j] < dsts[j-1]; j-- {
+			order[j], order[j-1] = order[j-1], order[j]
+			dsts[j], dsts[j-1] = dsts[j-1], dsts[j]
+		}
+	}
+	for i, child := range order {
+		if dsts[i] >= nearestBound(best, base, k, dstSqOf) {
+			break
+		}
+		best = q.nearest(child, center, base, k, best, dstSqOf)
+	}
+	return best
+}
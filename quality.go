@@ -0,0 +1,346 @@
+package gomisc
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// Statistical quality tests of generators and hashes.
+// Each returns a p-value, values very close to 0 (or 1) indicate a failure.
+
+// Result of a named quality test on `Subject`.
+type QualityResult struct {
+	Subject string
+	Name    string
+	P       f64
+}
+
+// Runs every quality test on every generator and hash seeded with `seed`.
+func QualityReport(seed u64) []QualityResult {
+	var result []QualityResult
+	for _, g := range qualitySources(seed) {
+		result = append(result, qualityNamed(g.name, qualitySource(g.src))...)
+	}
+	inputs := PCG64New(seed)
+	result = append(result, qualityNamed("Hash32", qualityHash32(&inputs, hash32Single))...)
+	return append(result, qualityNamed("Hash64", qualityHash64(&inputs, hash64Single))...)
+}
+
+type qualitySubject struct {
+	name string
+	src  Source
+}
+
+// Every generator seeded with `seed`.
+func qualitySources(seed u64) []qualitySubject {
+	mcg := MCG32New(seed)
+	pcgFast := PCG32FastNew(seed)
+	pcg := PCG32New(seed)
+	pcgStream := PCG32NewStream(seed, seed)
+	pcg64 := PCG64New(seed)
+	xss := Xoshiro256SSNew(seed)
+	xp := Xoshiro256PNew(seed)
+	sm := SplitMix64New(seed)
+	return []qualitySubject{
+		{"MCG32", &mcg},
+		{"PCG32Fast", &pcgFast},
+		{"PCG32", &pcg},
+		{"PCG32Stream", &pcgStream},
+		{"PCG64", &pcg64},
+		{"Xoshiro256SS", &xss},
+		{"Xoshiro256P", &xp},
+		{"SplitMix64", &sm},
+	}
+}
+
+func qualityNamed(subject string, results []QualityResult) []QualityResult {
+	for i := range results {
+		results[i].Subject = subject
+	}
+	return results
+}
+
+// Hash of a single value, as tested.
+func hash32Single(value u32) u32 {
+	return Hash32From(value)
+}
+
+// Hash of a single value, as tested.
+func hash64Single(value u64) u64 {
+	return Hash64From(value)
+}
+
+// Runs every generator test on `s`.
+func qualitySource(s Source) []QualityResult {
+	return []QualityResult{
+		{Name: "chi-square 256", P: chiSquareHighP(s, 256, 1<<20)},
+		{Name: "chi-square low bits", P: chiSquareLowP(s, 256, 1<<20)},
+		{Name: "serial correlation", P: serialCorrelationP(s, 1<<20)},
+		{Name: "birthday spacings", P: birthdaySpacingsP(s, 64)},
+	}
+}
+
+// Runs every hash test on `f`, inputs drawn from `s`.
+func qualityHash32(s Source, f func(u32) u32) []QualityResult {
+	return []QualityResult{
+		{Name: "avalanche", P: avalancheP32(s, f, 1<<12)},
+		{Name: "bit independence", P: bitIndependenceP32(s, f, 1<<10)},
+	}
+}
+
+// Runs every hash test on `f`, inputs drawn from `s`.
+func qualityHash64(s Source, f func(u64) u64) []QualityResult {
+	return []QualityResult{
+		{Name: "avalanche", P: avalancheP64(s, f, 1<<12)},
+		{Name: "bit independence", P: bitIndependenceP64(s, f, 1<<10)},
+	}
+}
+
+// Uniformity of the highest bits of Next over `buckets` (power of 2).
+func chiSquareHighP(s Source, buckets, samples int) f64 {
+	shift := 32 - bits.Len(uint(buckets-1))
+	counts := make([]int, buckets)
+	for i := 0; i < samples; i++ {
+		counts[s.Next()>>shift]++
+	}
+	return chiSquareCounts(counts, f64(samples)/f64(buckets))
+}
+
+// Uniformity of the lowest bits of Next over `buckets` (power of 2).
+func chiSquareLowP(s Source, buckets, samples int) f64 {
+	counts := make([]int, buckets)
+	for i := 0; i < samples; i++ {
+		counts[int(s.Next())&(buckets-1)]++
+	}
+	return chiSquareCounts(counts, f64(samples)/f64(buckets))
+}
+
+// Correlation between consecutive Float64 draws.
+func serialCorrelationP(s Source, samples int) f64 {
+	var sumX, sumXX, sumXY f64
+	first := RandFloat64(s)
+	prev := first
+	for i := 0; i < samples; i++ {
+		x := prev
+		if i == samples-1 {
+			prev = first // Wrap around so both series have equal moments
+		} else {
+			prev = RandFloat64(s)
+		}
+		sumX += x
+		sumXX += x * x
+		sumXY += x * prev
+	}
+	n := f64(samples)
+	mean := sumX / n
+	r := (sumXY/n - mean*mean) / (sumXX/n - mean*mean)
+	return math.Erfc(Abs(r) * Sqrt(n) / Sqrt2)
+}
+
+// Marsaglia's birthday spacings, 4096 birthdays in a 2^32 day year per trial.
+func birthdaySpacingsP(s Source, trials int) f64 {
+	const days, count = 1 << 32, 4096
+	birthdays := make([]u32, count)
+	spacings := make([]u32, count-1)
+	duplicates := 0
+	for t := 0; t < trials; t++ {
+		for i := range birthdays {
+			birthdays[i] = s.Next()
+		}
+		sort.Slice(birthdays, func(i, j int) bool { return birthdays[i] < birthdays[j] })
+		for i := range spacings {
+			spacings[i] = birthdays[i+1] - birthdays[i]
+		}
+		sort.Slice(spacings, func(i, j int) bool { return spacings[i] < spacings[j] })
+		for i := 1; i < len(spacings); i++ {
+			duplicates += BToI(spacings[i] == spacings[i-1])
+		}
+	}
+	// Duplicates are Poisson distributed
+	mean := f64(trials) * count * count * count / (4 * days)
+	below := gammaQ(f64(duplicates+1), mean)
+	above := 1 - gammaQ(f64(duplicates), mean)
+	if duplicates == 0 {
+		above = 1
+	}
+	return Min(1, 2*Min(below, above))
+}
+
+// Each input bit flip should flip each output bit with 1/2 chance.
+func avalancheP32(s Source, f func(u32) u32, samples int) f64 {
+	var flips [32][32]int
+	for n := 0; n < samples; n++ {
+		input := s.Next()
+		output := f(input)
+		for i := range flips {
+			diff := output ^ f(input^1<<i)
+			for j := range flips[i] {
+				flips[i][j] += int(diff >> j & 1)
+			}
+		}
+	}
+	counts := make([]int, 0, 32*32)
+	for i := range flips {
+		counts = append(counts, flips[i][:]...)
+	}
+	return chiSquareBinary(counts, samples)
+}
+
+// Each input bit flip should flip each output bit with 1/2 chance.
+func avalancheP64(s Source, f func(u64) u64, samples int) f64 {
+	var flips [64][64]int
+	for n := 0; n < samples; n++ {
+		input := s.Next64()
+		output := f(input)
+		for i := range flips {
+			diff := output ^ f(input^1<<i)
+			for j := range flips[i] {
+				flips[i][j] += int(diff >> j & 1)
+			}
+		}
+	}
+	counts := make([]int, 0, 64*64)
+	for i := range flips {
+		counts = append(counts, flips[i][:]...)
+	}
+	return chiSquareBinary(counts, samples)
+}
+
+// Output bit flips caused by an input bit flip should be pairwise independent.
+func bitIndependenceP32(s Source, f func(u32) u32, samples int) f64 {
+	counts := make([]int, 32*32*32)
+	for n := 0; n < samples; n++ {
+		input := s.Next()
+		output := f(input)
+		for i := 0; i < 32; i++ {
+			diff := output ^ f(input^1<<i)
+			for j := 0; j < 32; j++ {
+				// Bit k of `pairs` is set when output bits j and k flipped differently
+				pairs := diff ^ -(diff >> j & 1)
+				for k := j + 1; k < 32; k++ {
+					counts[(i*32+j)*32+k] += int(pairs >> k & 1)
+				}
+			}
+		}
+	}
+	return chiSquareBinary(pairCounts(counts, 32), samples)
+}
+
+// Output bit flips caused by an input bit flip should be pairwise independent.
+func bitIndependenceP64(s Source, f func(u64) u64, samples int) f64 {
+	counts := make([]int, 64*64*64)
+	for n := 0; n < samples; n++ {
+		input := s.Next64()
+		output := f(input)
+		for i := 0; i < 64; i++ {
+			diff := output ^ f(input^1<<i)
+			for j := 0; j < 64; j++ {
+				// Bit k of `pairs` is set when output bits j and k flipped differently
+				pairs := diff ^ -(diff >> j & 1)
+				for k := j + 1; k < 64; k++ {
+					counts[(i*64+j)*64+k] += int(pairs >> k & 1)
+				}
+			}
+		}
+	}
+	return chiSquareBinary(pairCounts(counts, 64), samples)
+}
+
+// Length of the cycle `step` enters from `start` (Brent's algorithm).
+// False if not found within `limit` steps.
+func period(step func(u64) u64, start, limit u64) (u64, bool) {
+	power, length := u64(1), u64(1)
+	tortoise, hare := start, step(start)
+	for steps := u64(1); tortoise != hare; steps++ {
+		if steps >= limit {
+			return 0, false
+		}
+		if power == length {
+			tortoise, power, length = hare, power*2, 0
+		}
+		hare = step(hare)
+		length++
+	}
+	return length, true
+}
+
+// Counts of j < k pairs only.
+func pairCounts(counts []int, width int) []int {
+	result := make([]int, 0, width*width*(width-1)/2)
+	for i := 0; i < width; i++ {
+		for j := 0; j < width; j++ {
+			for k := j + 1; k < width; k++ {
+				result = append(result, counts[(i*width+j)*width+k])
+			}
+		}
+	}
+	return result
+}
+
+// Each of `counts` should be Binomial(samples, 1/2).
+func chiSquareBinary(counts []int, samples int) f64 {
+	mean, variance := f64(samples)/2, f64(samples)/4
+	x := 0.
+	for _, c := range counts {
+		d := f64(c) - mean
+		x += d * d / variance
+	}
+	return chiSquareP(x, f64(len(counts)))
+}
+
+// Each of `counts` should be near `expected`.
+func chiSquareCounts(counts []int, expected f64) f64 {
+	x := 0.
+	for _, c := range counts {
+		d := f64(c) - expected
+		x += d * d / expected
+	}
+	return chiSquareP(x, f64(len(counts)-1))
+}
+
+// Chance of chi-square statistic at least `x` with `df` degrees of freedom.
+func chiSquareP(x, df f64) f64 {
+	return gammaQ(df/2, x/2)
+}
+
+// Regularized upper incomplete gamma function, see Numerical Recipes 6.2.
+func gammaQ(a, x f64) f64 {
+	if x <= 0 {
+		return 1
+	}
+	logPrefix := a*math.Log(x) - x - lgamma(a)
+	if x < a+1 {
+		// Series
+		term, sum := 1/a, 1/a
+		for n := 1.; n < 1000 && Abs(term) > Abs(sum)*1e-15; n++ {
+			term *= x / (a + n)
+			sum += term
+		}
+		return 1 - sum*math.Exp(logPrefix)
+	}
+	// Continued fraction, modified Lentz
+	const tiny = 1e-300
+	b := x + 1 - a
+	c, d := 1/tiny, 1/b
+	h := d
+	for i := 1.; i < 1000; i++ {
+		an := -i * (i - a)
+		b += 2
+		d = an*d + b
+		if Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return math.Exp(logPrefix) * h
+}
@@ -0,0 +1,45 @@
+package gomisc
+
+import "testing"
+
+// p-values this close to 0 or 1 fail, seeds are fixed so results are stable.
+const qualityAlpha = 1e-4
+
+func testQuality(t *testing.T, results []QualityResult) {
+	t.Helper()
+	for _, r := range results {
+		if r.P < qualityAlpha || r.P > 1-qualityAlpha {
+			t.Errorf("%s: p = %v", r.Name, r.P)
+		}
+	}
+}
+
+func TestSourceQuality(t *testing.T) {
+	for _, g := range qualitySources(1) {
+		t.Run(g.name, func(t *testing.T) {
+			testQuality(t, qualitySource(g.src))
+		})
+	}
+}
+
+func TestHash32Quality(t *testing.T) {
+	s := PCG64New(1)
+	testQuality(t, qualityHash32(&s, hash32Single))
+}
+
+func TestHash64Quality(t *testing.T) {
+	s := PCG64New(1)
+	testQuality(t, qualityHash64(&s, hash64Single))
+}
+
+// Small-state variants expose multiplier and increment defects.
+func TestLCGPeriod(t *testing.T) {
+	const bits = 20
+	mask := u64(1)<<bits - 1
+	if got, _ := period(func(s u64) u64 { return s * pcg32Mul & mask }, 1, 1<<(bits+2)); got != 1<<(bits-2) {
+		t.Errorf("MCG period = %v, want %v", got, 1<<(bits-2))
+	}
+	if got, _ := period(func(s u64) u64 { return (s*pcg32Mul + pcg32Inc) & mask }, 0, 1<<(bits+2)); got != 1<<bits {
+		t.Errorf("LCG period = %v, want %v", got, 1<<bits)
+	}
+}
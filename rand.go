@@ -1,18 +1,17 @@
 package gomisc
 
-import (
-	"os"
-	"time"
-)
-
 // TODO play with rand
 
 // golden ratio: sqrt(5) / 2 - .5
 
 // Scrambles bits from multiple low quality random bits
+// Zero value is usable as hash.Hash32, Mix needs Hash32Empty.
 type Hash32 struct {
 	result  u32
 	hashNum u32
+	tail    u32 // Pending bytes of Write
+	tailLen u8
+	length  u64
 }
 
 // Mix in another number
@@ -28,12 +27,22 @@ func (h Hash32) Mix(value u32) Hash32 {
 
 // Scrambles bits from multiple low quality random bits
 func Hash32New(value u32) Hash32 {
-	return Hash32{0x9e3779b9, 0x43b0d7e5}.Mix(value)
+	return Hash32Empty().Mix(value)
 }
 
-// Returns the resulting bits
+// Hash with nothing mixed in yet
+func Hash32Empty() Hash32 {
+	return Hash32{result: 0x9e3779b9, hashNum: 0x43b0d7e5}
+}
+
+// Returns the resulting bits, finalized so every input bit affects every output bit.
+// Finalizer is triple32, see https://github.com/skeeto/hash-prospector
 func (g Hash32) Result() u32 {
-	return g.result
+	result := g.result
+	result = (result ^ result>>17) * 0xed5ad4bb
+	result = (result ^ result>>11) * 0xac4c1b51
+	result = (result ^ result>>15) * 0x31848bab
+	return result ^ result>>14
 }
 
 // Scrambles bits from multiple low quality random bits
@@ -46,25 +55,37 @@ func Hash32From(values ...u32) u32 {
 }
 
 // Scrambles bits from multiple low quality random bits
+// Zero value is usable as hash.Hash64, Mix needs Hash64Empty.
 type Hash64 struct {
 	result  u64
 	hashNum u64
+	tail    u64 // Pending bytes of Write
+	tailLen u8
+	length  u64
 }
 
 // Mix in another number
 func (g Hash64) Mix(value u64) Hash64 {
 	value ^= g.hashNum
-	g.hashNum *= 0x931e8875
+	value ^= value >> 32
+	g.hashNum *= 0xd1342543de82ef95
 	value *= g.hashNum
+	value ^= value >> 29
+	value *= 0xbf58476d1ce4e5b9
 	value ^= value >> 32
-	g.result = g.result*0xca01f9dd - value*0x4973f715
+	g.result = g.result*0xbf58476d1ce4e5b9 - value*0x94d049bb133111eb
 	g.result ^= g.result >> 32
 	return g
 }
 
 // Scrambles bits from multiple low quality random bits
 func Hash64New(value u64) Hash64 {
-	return Hash64{0x9e3779b97f4a7c15, 0x43b0d7e5}.Mix(value)
+	return Hash64Empty().Mix(value)
+}
+
+// Hash with nothing mixed in yet
+func Hash64Empty() Hash64 {
+	return Hash64{result: 0x9e3779b97f4a7c15, hashNum: 0x9e3779b97f4a7c15}
 }
 
 // Returns the resulting bits
@@ -81,11 +102,6 @@ func Hash64From(values ...u64) u64 {
 	return result.Result()
 }
 
-// Generates a seed based on time and pid
-func SeedGen64() u64 {
-	return Hash64From(u64(time.Now().UnixNano()), u64(os.Getpid()))
-}
-
 type MCG32 u64
 
 // Initializes with seed
@@ -98,6 +114,11 @@ func MCG32Init() MCG32 {
 	return MCG32New(SeedGen64())
 }
 
+// Reinitializes with seed
+func (s *MCG32) Seed(seed u64) {
+	*s = MCG32New(seed)
+}
+
 func (s *MCG32) raw() u64 {
 	state := *s
 	*s = state * 0xf13283ad
@@ -109,21 +130,101 @@ func (s *MCG32) Next() u32 {
 	return u32(s.raw() >> 32)
 }
 
+// Skips `delta` steps in O(log delta).
+func (s *MCG32) Advance(delta u64) {
+	*s = MCG32(lcgAdvance(u64(*s), 0xf13283ad, 0, delta))
+}
+
+// Goes back `delta` steps in O(log delta).
+func (s *MCG32) Rewind(delta u64) {
+	s.Advance(-delta)
+}
+
+// Derives an independent generator.
+func (s *MCG32) Split() MCG32 {
+	return MCG32New(mix64(s.Next64()))
+}
+
+// Generates a random uint64 number
+func (s *MCG32) Next64() u64 {
+	return u64(s.Next())<<32 | u64(s.Next())
+}
+
 // Generates number in range [0,n)
 // The larger the n the larger the bias (in general)
 // Usualy in practice it is insignificant
 func (s *MCG32) Range(n int) int {
-	return int(s.Next() % u32(n))
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *MCG32) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *MCG32) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *MCG32) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *MCG32) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *MCG32) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *MCG32) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
 }
 
 // Generates number in range [0,1]
 func (s *MCG32) Normal32() f32 {
-	return f32(s.raw()) / f32(1<<32-1)
+	return RandNormal32(s)
 }
 
-// Generates number in range [0,1]
+// Generates number in range [0,1]. Has 32bit resolution
 func (s *MCG32) Normal64() f64 {
-	return f64(s.raw()) / f64(1<<32-1)
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *MCG32) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *MCG32) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *MCG32) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *MCG32) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *MCG32) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *MCG32) Float64Closed() f64 {
+	return RandFloat64Closed(s)
 }
 
 type PCG32Fast u64
@@ -138,6 +239,11 @@ func PCG32FastInit() PCG32Fast {
 	return PCG32FastNew(SeedGen64())
 }
 
+// Reinitializes with seed
+func (s *PCG32Fast) Seed(seed u64) {
+	*s = PCG32FastNew(seed)
+}
+
 // Generates a random uint32 number
 func (s *PCG32Fast) Next() u32 {
 	state := *s
@@ -145,25 +251,108 @@ func (s *PCG32Fast) Next() u32 {
 	return u32((state ^ state>>22) >> (22 + state>>61))
 }
 
+// Skips `delta` steps in O(log delta).
+func (s *PCG32Fast) Advance(delta u64) {
+	*s = PCG32Fast(lcgAdvance(u64(*s), 0xf13283ad, 0, delta))
+}
+
+// Goes back `delta` steps in O(log delta).
+func (s *PCG32Fast) Rewind(delta u64) {
+	s.Advance(-delta)
+}
+
+// Derives an independent generator.
+func (s *PCG32Fast) Split() PCG32Fast {
+	return PCG32FastNew(mix64(s.Next64()))
+}
+
+// Generates a random uint64 number
+func (s *PCG32Fast) Next64() u64 {
+	return u64(s.Next())<<32 | u64(s.Next())
+}
+
 // Generates number in range [0,n)
 // The larger the n the larger the bias (in general)
 // Usualy in practice it is insignificant
 func (s *PCG32Fast) Range(n int) int {
-	return int(s.Next() % u32(n))
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG32Fast) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG32Fast) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG32Fast) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG32Fast) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *PCG32Fast) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *PCG32Fast) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
 }
 
 // Generates number in range [0,1]
 func (s *PCG32Fast) Normal32() f32 {
-	return f32(s.Next()) / f32(1<<32-1)
+	return RandNormal32(s)
 }
 
 // Generates number in range [0,1]. Has 32bit resolution
 func (s *PCG32Fast) Normal64() f64 {
-	return f64(s.Next()) / f64(1<<32-1)
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *PCG32Fast) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *PCG32Fast) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *PCG32Fast) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *PCG32Fast) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *PCG32Fast) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *PCG32Fast) Float64Closed() f64 {
+	return RandFloat64Closed(s)
 }
 
 type PCG32 u64
 
+const pcg32Mul = 0xf13283ad
+const pcg32Inc = 0x9e3779b97f4a7c15
+
 // Initializes with seed
 func PCG32New(seed u64) PCG32 {
 	return PCG32(seed)
@@ -174,26 +363,262 @@ func PCG32Init() PCG32 {
 	return PCG32New(SeedGen64())
 }
 
+// Reinitializes with seed
+func (s *PCG32) Seed(seed u64) {
+	*s = PCG32New(seed)
+}
+
 // Generates a random uint32 number
 func (s *PCG32) Next() u32 {
 	state := u64(*s)
-	*s = PCG32(state*0xf13283ad + 0x9e3779b97f4a7c15)
+	*s = PCG32(state*pcg32Mul + pcg32Inc)
 	return RotateU32(u32((state^state>>18)>>27), u8(state>>59))
 }
 
+// Skips `delta` steps in O(log delta).
+func (s *PCG32) Advance(delta u64) {
+	*s = PCG32(lcgAdvance(u64(*s), pcg32Mul, pcg32Inc, delta))
+}
+
+// Goes back `delta` steps in O(log delta).
+func (s *PCG32) Rewind(delta u64) {
+	s.Advance(-delta)
+}
+
+// Derives a generator starting at a random point of the same stream.
+// Use PCG32Stream for generators that can't overlap.
+func (s *PCG32) Split() PCG32 {
+	return PCG32New(mix64(s.Next64()))
+}
+
+// Generates a random uint64 number
+func (s *PCG32) Next64() u64 {
+	return u64(s.Next())<<32 | u64(s.Next())
+}
+
 // Generates number in range [0,n)
 // The larger the n the larger the bias (in general)
 // Usualy in practice it is insignificant
 func (s *PCG32) Range(n int) int {
-	return int(s.Next() % u32(n))
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG32) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG32) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG32) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG32) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *PCG32) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *PCG32) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
 }
 
 // Generates number in range [0,1]
 func (s *PCG32) Normal32() f32 {
-	return f32(s.Next()) / f32(1<<32-1)
+	return RandNormal32(s)
 }
 
 // Generates number in range [0,1]. Has 32bit resolution
 func (s *PCG32) Normal64() f64 {
-	return f64(s.Next()) / f64(1<<32-1)
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *PCG32) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *PCG32) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *PCG32) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *PCG32) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *PCG32) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *PCG32) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
+
+// PCG32 on a selectable stream, different streams produce independent sequences.
+type PCG32Stream struct {
+	state, inc u64
+}
+
+// Initializes with seed on `stream`.
+func PCG32NewStream(seed, stream u64) PCG32Stream {
+	return PCG32Stream{seed, stream<<1 | 1}
+}
+
+// Initializes seed and stream with SeedGen64
+func PCG32InitStream() PCG32Stream {
+	return PCG32NewStream(SeedGen64(), SeedGen64())
+}
+
+// Reinitializes with seed, keeping the stream
+func (s *PCG32Stream) Seed(seed u64) {
+	s.state = seed
+}
+
+// Stream selected at creation.
+func (s *PCG32Stream) Stream() u64 {
+	return s.inc >> 1
+}
+
+// Generates a random uint32 number
+func (s *PCG32Stream) Next() u32 {
+	state := s.state
+	s.state = state*pcg32Mul + s.inc
+	return RotateU32(u32((state^state>>18)>>27), u8(state>>59))
+}
+
+// Skips `delta` steps in O(log delta).
+func (s *PCG32Stream) Advance(delta u64) {
+	s.state = lcgAdvance(s.state, pcg32Mul, s.inc, delta)
+}
+
+// Goes back `delta` steps in O(log delta).
+func (s *PCG32Stream) Rewind(delta u64) {
+	s.Advance(-delta)
+}
+
+// Derives an independent generator on a different stream.
+func (s *PCG32Stream) Split() PCG32Stream {
+	return PCG32NewStream(mix64(s.Next64()), s.Next64())
+}
+
+// Generates a random uint64 number
+func (s *PCG32Stream) Next64() u64 {
+	return u64(s.Next())<<32 | u64(s.Next())
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (s *PCG32Stream) Range(n int) int {
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG32Stream) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG32Stream) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG32Stream) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG32Stream) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *PCG32Stream) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *PCG32Stream) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
+}
+
+// Generates number in range [0,1]
+func (s *PCG32Stream) Normal32() f32 {
+	return RandNormal32(s)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (s *PCG32Stream) Normal64() f64 {
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *PCG32Stream) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *PCG32Stream) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *PCG32Stream) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *PCG32Stream) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *PCG32Stream) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *PCG32Stream) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
+
+// LCG jump ahead, see Brown "Random Number Generation with Arbitrary Strides"
+func lcgAdvance(state, mul, inc, delta u64) u64 {
+	accMul, accInc := u64(1), u64(0)
+	for ; delta > 0; delta >>= 1 {
+		if delta&1 == 1 {
+			accMul *= mul
+			accInc = accInc*mul + inc
+		}
+		inc *= mul + 1
+		mul *= mul
+	}
+	return accMul*state + accInc
+}
+
+// SplitMix64 finalizer, scrambles all bits of `value`.
+func mix64(value u64) u64 {
+	value = (value ^ value>>30) * 0xbf58476d1ce4e5b9
+	value = (value ^ value>>27) * 0x94d049bb133111eb
+	return value ^ value>>31
 }
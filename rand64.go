@@ -0,0 +1,565 @@
+package gomisc
+
+// SplitMix64, see https://prng.di.unimi.it/splitmix64.c
+// Mostly used for seeding other generators.
+type SplitMix64 u64
+
+// Initializes with seed
+func SplitMix64New(seed u64) SplitMix64 {
+	return SplitMix64(seed)
+}
+
+// Initializes with SeedGen64
+func SplitMix64Init() SplitMix64 {
+	return SplitMix64New(SeedGen64())
+}
+
+// Reinitializes with seed
+func (s *SplitMix64) Seed(seed u64) {
+	*s = SplitMix64New(seed)
+}
+
+// Generates a random uint64 number
+func (s *SplitMix64) Next64() u64 {
+	*s += 0x9e3779b97f4a7c15
+	return mix64(u64(*s))
+}
+
+// Skips `delta` steps in O(1).
+func (s *SplitMix64) Advance(delta u64) {
+	*s += SplitMix64(delta * 0x9e3779b97f4a7c15)
+}
+
+// Goes back `delta` steps in O(1).
+func (s *SplitMix64) Rewind(delta u64) {
+	s.Advance(-delta)
+}
+
+// Derives an independent generator.
+func (s *SplitMix64) Split() SplitMix64 {
+	return SplitMix64New(mix64(s.Next64()))
+}
+
+// Generates a random uint32 number
+func (s *SplitMix64) Next() u32 {
+	return u32(s.Next64() >> 32)
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (s *SplitMix64) Range(n int) int {
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *SplitMix64) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *SplitMix64) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *SplitMix64) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *SplitMix64) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *SplitMix64) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *SplitMix64) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
+}
+
+// Generates number in range [0,1]
+func (s *SplitMix64) Normal32() f32 {
+	return RandNormal32(s)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (s *SplitMix64) Normal64() f64 {
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *SplitMix64) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *SplitMix64) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *SplitMix64) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *SplitMix64) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *SplitMix64) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *SplitMix64) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
+
+// xoshiro256**, see https://prng.di.unimi.it/xoshiro256starstar.c
+// State must not be all zeros.
+type Xoshiro256SS [4]u64
+
+// Initializes with seed
+func Xoshiro256SSNew(seed u64) Xoshiro256SS {
+	return Xoshiro256SS(xoshiro256Seed(seed))
+}
+
+// Initializes with SeedGen64
+func Xoshiro256SSInit() Xoshiro256SS {
+	return Xoshiro256SSNew(SeedGen64())
+}
+
+// Reinitializes with seed
+func (s *Xoshiro256SS) Seed(seed u64) {
+	*s = Xoshiro256SSNew(seed)
+}
+
+// Generates a random uint64 number
+func (s *Xoshiro256SS) Next64() u64 {
+	result := RotateU64(s[1]*5, 7) * 9
+	xoshiro256Step((*[4]u64)(s))
+	return result
+}
+
+// Skips 2^128 steps, producing non-overlapping sequences for parallel use.
+func (s *Xoshiro256SS) Jump() {
+	xoshiro256Jump((*[4]u64)(s), xoshiro256JumpPoly)
+}
+
+// Skips 2^192 steps, producing non-overlapping sequences for distributed use.
+func (s *Xoshiro256SS) LongJump() {
+	xoshiro256Jump((*[4]u64)(s), xoshiro256LongJumpPoly)
+}
+
+// Derives an independent generator.
+func (s *Xoshiro256SS) Split() Xoshiro256SS {
+	return Xoshiro256SS{mix64(s.Next64()), mix64(s.Next64()), mix64(s.Next64()), mix64(s.Next64())}
+}
+
+// Generates a random uint32 number
+func (s *Xoshiro256SS) Next() u32 {
+	return u32(s.Next64() >> 32)
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (s *Xoshiro256SS) Range(n int) int {
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *Xoshiro256SS) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *Xoshiro256SS) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *Xoshiro256SS) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *Xoshiro256SS) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *Xoshiro256SS) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *Xoshiro256SS) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
+}
+
+// Generates number in range [0,1]
+func (s *Xoshiro256SS) Normal32() f32 {
+	return RandNormal32(s)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (s *Xoshiro256SS) Normal64() f64 {
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *Xoshiro256SS) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *Xoshiro256SS) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *Xoshiro256SS) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *Xoshiro256SS) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *Xoshiro256SS) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *Xoshiro256SS) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
+
+// xoshiro256+, see https://prng.di.unimi.it/xoshiro256plus.c
+// Faster than Xoshiro256SS, but the lowest bits are of low quality.
+// State must not be all zeros.
+type Xoshiro256P [4]u64
+
+// Initializes with seed
+func Xoshiro256PNew(seed u64) Xoshiro256P {
+	return Xoshiro256P(xoshiro256Seed(seed))
+}
+
+// Initializes with SeedGen64
+func Xoshiro256PInit() Xoshiro256P {
+	return Xoshiro256PNew(SeedGen64())
+}
+
+// Reinitializes with seed
+func (s *Xoshiro256P) Seed(seed u64) {
+	*s = Xoshiro256PNew(seed)
+}
+
+// Generates a random uint64 number
+func (s *Xoshiro256P) Next64() u64 {
+	result := s[0] + s[3]
+	xoshiro256Step((*[4]u64)(s))
+	return result
+}
+
+// Skips 2^128 steps, producing non-overlapping sequences for parallel use.
+func (s *Xoshiro256P) Jump() {
+	xoshiro256Jump((*[4]u64)(s), xoshiro256JumpPoly)
+}
+
+// Skips 2^192 steps, producing non-overlapping sequences for distributed use.
+func (s *Xoshiro256P) LongJump() {
+	xoshiro256Jump((*[4]u64)(s), xoshiro256LongJumpPoly)
+}
+
+// Derives an independent generator.
+func (s *Xoshiro256P) Split() Xoshiro256P {
+	return Xoshiro256P{mix64(s.Next64()), mix64(s.Next64()), mix64(s.Next64()), mix64(s.Next64())}
+}
+
+// Generates a random uint32 number
+func (s *Xoshiro256P) Next() u32 {
+	return u32(s.Next64() >> 32)
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (s *Xoshiro256P) Range(n int) int {
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *Xoshiro256P) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *Xoshiro256P) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *Xoshiro256P) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *Xoshiro256P) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *Xoshiro256P) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *Xoshiro256P) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
+}
+
+// Generates number in range [0,1]
+func (s *Xoshiro256P) Normal32() f32 {
+	return RandNormal32(s)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (s *Xoshiro256P) Normal64() f64 {
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *Xoshiro256P) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *Xoshiro256P) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *Xoshiro256P) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *Xoshiro256P) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *Xoshiro256P) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *Xoshiro256P) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
+
+func xoshiro256Seed(seed u64) [4]u64 {
+	gen := SplitMix64New(seed)
+	return [4]u64{gen.Next64(), gen.Next64(), gen.Next64(), gen.Next64()}
+}
+
+var xoshiro256JumpPoly = [4]u64{
+	0x180ec6d33cfd0aba, 0xd5a61266f0c9392c, 0xa9582618e03fc9aa, 0x39abdc4529b1661c,
+}
+var xoshiro256LongJumpPoly = [4]u64{
+	0x76e15d3efefdcbbf, 0xc5004e441c522fb3, 0x77710069854ee241, 0x39109bb02acbe635,
+}
+
+func xoshiro256Jump(s *[4]u64, poly [4]u64) {
+	var result [4]u64
+	for _, word := range poly {
+		for b := 0; b < 64; b++ {
+			if word&(1<<b) != 0 {
+				for i := range result {
+					result[i] ^= s[i]
+				}
+			}
+			xoshiro256Step(s)
+		}
+	}
+	*s = result
+}
+
+func xoshiro256Step(s *[4]u64) {
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = RotateU64(s[3], 45)
+}
+
+// PCG XSL-RR 128/64, see https://www.pcg-random.org
+type PCG64 struct {
+	state, inc u128
+}
+
+var pcg64Mul = u128{0x2360ed051fc65da4, 0x4385df649fccf645}
+var pcg64Inc = u128{0x5851f42d4c957f2d, 0x14057b7ef767814f}
+
+// Initializes with seed
+func PCG64New(seed u64) PCG64 {
+	return pcg64Seeded(seed, pcg64Inc)
+}
+
+// Initializes with seed on a selected stream.
+// Different streams produce independent sequences.
+func PCG64NewStream(seed, stream u64) PCG64 {
+	return pcg64Seeded(seed, u128{stream >> 63, stream<<1 | 1})
+}
+
+func pcg64Seeded(seed u64, inc u128) PCG64 {
+	s := PCG64{u128{}, inc}
+	s.step()
+	s.state = s.state.add(u128{0, seed})
+	s.step()
+	return s
+}
+
+// Initializes with SeedGen64
+func PCG64Init() PCG64 {
+	return PCG64New(SeedGen64())
+}
+
+// Reinitializes with seed
+func (s *PCG64) Seed(seed u64) {
+	*s = PCG64New(seed)
+}
+
+func (s *PCG64) step() {
+	s.state = s.state.mul(pcg64Mul).add(s.inc)
+}
+
+// Generates a random uint64 number
+func (s *PCG64) Next64() u64 {
+	s.step()
+	return RotateU64(s.state.hi^s.state.lo, u8(64-s.state.hi>>58))
+}
+
+// Skips `delta` steps in O(log delta).
+func (s *PCG64) Advance(delta u64) {
+	s.advance(u128{0, delta})
+}
+
+// Goes back `delta` steps in O(log delta).
+func (s *PCG64) Rewind(delta u64) {
+	s.advance(u128{}.sub(u128{0, delta}))
+}
+
+func (s *PCG64) advance(delta u128) {
+	accMul, accInc := u128{0, 1}, u128{}
+	mul, inc := pcg64Mul, s.inc
+	for ; delta != (u128{}); delta = delta.shr1() {
+		if delta.lo&1 == 1 {
+			accMul = accMul.mul(mul)
+			accInc = accInc.mul(mul).add(inc)
+		}
+		inc = inc.mul(mul.add(u128{0, 1}))
+		mul = mul.mul(mul)
+	}
+	s.state = s.state.mul(accMul).add(accInc)
+}
+
+// Derives an independent generator on a different stream.
+func (s *PCG64) Split() PCG64 {
+	return PCG64NewStream(mix64(s.Next64()), s.Next64())
+}
+
+// Generates a random uint32 number
+func (s *PCG64) Next() u32 {
+	return u32(s.Next64() >> 32)
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (s *PCG64) Range(n int) int {
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG64) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG64) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG64) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *PCG64) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *PCG64) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *PCG64) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
+}
+
+// Generates number in range [0,1]
+func (s *PCG64) Normal32() f32 {
+	return RandNormal32(s)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (s *PCG64) Normal64() f64 {
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *PCG64) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *PCG64) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *PCG64) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *PCG64) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *PCG64) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *PCG64) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
@@ -0,0 +1,73 @@
+package gomisc
+
+import "testing"
+
+// First outputs of the reference implementations for known seeds.
+
+func testNext64(t *testing.T, name string, next func() u64, want []u64) {
+	t.Helper()
+	for i, w := range want {
+		if got := next(); got != w {
+			t.Fatalf("%s output %d = %#x, want %#x", name, i, got, w)
+		}
+	}
+}
+
+// pcg64 from pcg-c, pcg64_srandom_r(42, 54).
+func TestPCG64Reference(t *testing.T) {
+	s := PCG64NewStream(42, 54)
+	testNext64(t, "PCG64", s.Next64, []u64{
+		0x86b1da1d72062b68,
+		0x1304aa46c9853d39,
+		0xa3670e9e0dd50358,
+		0xf9090e529a7dae00,
+		0xc85b9fd837996f2c,
+		0x606121f8e3919196,
+	})
+}
+
+// xoshiro256** from prng.di.unimi.it, state {1, 2, 3, 4}.
+func TestXoshiro256SSReference(t *testing.T) {
+	s := Xoshiro256SS{1, 2, 3, 4}
+	testNext64(t, "Xoshiro256SS", s.Next64, []u64{
+		11520,
+		0,
+		1509978240,
+		1215971899390074240,
+		1216172134540287360,
+		607988272756665600,
+		16172922978634559625,
+		8476171486693032832,
+		10595114339597558777,
+		2904607092377533576,
+	})
+}
+
+// xoshiro256+ from prng.di.unimi.it, state {1, 2, 3, 4}.
+func TestXoshiro256PReference(t *testing.T) {
+	s := Xoshiro256P{1, 2, 3, 4}
+	testNext64(t, "Xoshiro256P", s.Next64, []u64{
+		5,
+		211106232532999,
+		211106635186183,
+		9223759065350669058,
+		9250833439874351877,
+		13862484359527728515,
+		2346507365006083650,
+		1168864526675804870,
+		34095955243042024,
+		3466914240207415127,
+	})
+}
+
+// splitmix64 from prng.di.unimi.it, seed 1234567.
+func TestSplitMix64Reference(t *testing.T) {
+	s := SplitMix64New(1234567)
+	testNext64(t, "SplitMix64", s.Next64, []u64{
+		6457827717110365317,
+		3203168211198807973,
+		9817491932198370423,
+		4593380528125082431,
+		16408922859458223821,
+	})
+}
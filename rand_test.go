@@ -0,0 +1,56 @@
+package gomisc
+
+import "testing"
+
+type advancer interface {
+	Source
+	Advance(delta u64)
+	Rewind(delta u64)
+}
+
+// Every generator that can jump, seeded the same on each call.
+func advancers() map[string]func() advancer {
+	return map[string]func() advancer{
+		"MCG32":       func() advancer { s := MCG32New(1); return &s },
+		"PCG32Fast":   func() advancer { s := PCG32FastNew(1); return &s },
+		"PCG32":       func() advancer { s := PCG32New(1); return &s },
+		"PCG32Stream": func() advancer { s := PCG32NewStream(1, 2); return &s },
+		"SplitMix64":  func() advancer { s := SplitMix64New(1); return &s },
+		"PCG64":       func() advancer { s := PCG64NewStream(1, 2); return &s },
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	for name, seeded := range advancers() {
+		for _, n := range []u64{0, 1, 2, 7, 1000} {
+			jumped, stepped := seeded(), seeded()
+			jumped.Advance(n)
+			for i := u64(0); i < n; i++ {
+				stepped.Next()
+			}
+			if got, want := jumped.Next64(), stepped.Next64(); got != want {
+				t.Errorf("%s Advance(%v) then Next64 = %#x, want %#x", name, n, got, want)
+			}
+		}
+	}
+}
+
+func TestRewind(t *testing.T) {
+	for name, seeded := range advancers() {
+		for _, n := range []u64{1, 7, 1000, 1 << 40} {
+			s := seeded()
+			s.Advance(n)
+			s.Rewind(n)
+			if got, want := s.Next64(), seeded().Next64(); got != want {
+				t.Errorf("%s Rewind(%v) after Advance then Next64 = %#x, want %#x", name, n, got, want)
+			}
+		}
+		// Rewind also undoes Next
+		s := seeded()
+		s.Next()
+		s.Rewind(1)
+		if got, want := s.Next64(), seeded().Next64(); got != want {
+			t.Errorf("%s Rewind(1) after Next then Next64 = %#x, want %#x", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package gomisc
+
+// Randomly reorders `slice` in place (Fisher-Yates).
+func Shuffle[T any](s Source, slice []T) {
+	for i := len(slice) - 1; i > 0; i-- {
+		j := RandIntN(s, i+1)
+		slice[i], slice[j] = slice[j], slice[i]
+	}
+}
+
+// Random permutation of numbers in range [0,n).
+func Perm(s Source, n int) []int {
+	result := make([]int, n)
+	for i := range result {
+		j := RandIntN(s, i+1)
+		result[i], result[j] = result[j], i
+	}
+	return result
+}
+
+// Random element of `slice`.
+func Choice[T any](s Source, slice []T) T {
+	PanicIf(len(slice) == 0, "Can't choose from an empty slice")
+	return slice[RandIntN(s, len(slice))]
+}
+
+// `n` random elements of `slice` without replacement, in random order.
+func ChooseN[T any](s Source, slice []T, n int) []T {
+	PanicIf(n < 0 || n > len(slice),
+		"Can't choose more elements than the slice holds",
+	)
+	result := make([]T, n)
+	if n*4 < len(slice) {
+		// Floyd's algorithm, avoids copying the whole slice
+		chosen := make(map[int]struct{}, n)
+		for i := len(slice) - n; i < len(slice); i++ {
+			j := RandIntN(s, i+1)
+			if _, ok := chosen[j]; ok {
+				j = i
+			}
+			chosen[j] = struct{}{}
+			result[i-len(slice)+n] = slice[j]
+		}
+		Shuffle(s, result)
+		return result
+	}
+	// Partial Fisher-Yates on a copy
+	pool := SliceNewCopy(slice, len(slice))
+	for i := range result {
+		j := RandIntRange(s, i, len(pool))
+		pool[i], pool[j] = pool[j], pool[i]
+		result[i] = pool[i]
+	}
+	return result
+}
+
+// Uniform sample of `k` elements from a stream of unknown length.
+type Reservoir[T any] struct {
+	src   Source
+	items []T
+	seen  int
+}
+
+// Initializes with sample size `k`.
+func ReservoirNew[T any](s Source, k int) Reservoir[T] {
+	PanicIf(k <= 0, "Reservoir size must be positive")
+	return Reservoir[T]{s, make([]T, 0, k), 0}
+}
+
+// Offer `value` from the stream.
+func (r *Reservoir[T]) Add(value T) {
+	r.seen++
+	if len(r.items) < cap(r.items) {
+		r.items = append(r.items, value)
+	} else if i := RandIntN(r.src, r.seen); i < len(r.items) {
+		r.items[i] = value
+	}
+}
+
+// Elements offered so far.
+func (r Reservoir[T]) Seen() int {
+	return r.seen
+}
+
+// The current sample, fewer than `k` if not enough were offered.
+func (r Reservoir[T]) Items() []T {
+	return r.items
+}
+
+// Uniform sample of `k` elements pulled from `next` until it is exhausted.
+func ReservoirSample[T any](s Source, k int, next func() (T, bool)) []T {
+	r := ReservoirNew[T](s, k)
+	for value, ok := next(); ok; value, ok = next() {
+		r.Add(value)
+	}
+	return r.Items()
+}
+
+// Precomputed weighted choice with O(1) draws (Vose's alias method).
+type AliasTable struct {
+	chance []f64
+	alias  []int
+}
+
+// Builds table from non-negative `weights`.
+func AliasTableNew(weights []f64) AliasTable {
+	n := len(weights)
+	total := 0.
+	for _, w := range weights {
+		PanicIf(w < 0, "Weights must be non-negative")
+		total += w
+	}
+	PanicIf(total <= 0, "Weights must sum to a positive value")
+	table := AliasTable{make([]f64, n), make([]int, n)}
+	scaled := make([]f64, n)
+	small, large := make([]int, 0, n), make([]int, 0, n)
+	for i, w := range weights {
+		scaled[i] = w * f64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		l, g := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		table.chance[l], table.alias[l] = scaled[l], g
+		scaled[g] += scaled[l] - 1
+		if scaled[g] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, g)
+		}
+	}
+	// Leftovers are 1 up to rounding error
+	for _, i := range append(small, large...) {
+		table.chance[i], table.alias[i] = 1, i
+	}
+	return table
+}
+
+// Number of weights.
+func (a AliasTable) Len() int {
+	return len(a.chance)
+}
+
+// Index with chance proportional to its weight.
+func (a AliasTable) Next(s Source) int {
+	i := RandIntN(s, len(a.chance))
+	if RandFloat64(s) < a.chance[i] {
+		return i
+	}
+	return a.alias[i]
+}
@@ -0,0 +1,176 @@
+package gomisc
+
+import "math/bits"
+
+// Candidates tried around each active point before it is retired.
+const poissonAttempts = 30
+
+// Points in rectangle `min`-`max` no closer than `radius` (Bridson's algorithm).
+func PoissonDisk(s Source, min, max Vector2, radius f64) []Vector2 {
+	return PoissonDiskVariable(s, min, max, radius, radius, func(Vector2) f64 { return radius })
+}
+
+// Points in `polygon` no closer than `radius` (Bridson's algorithm).
+func PoissonDiskPolygon(s Source, polygon []Vector2, radius f64) []Vector2 {
+	min, max := polygonBounds(polygon)
+	return poissonDisk(s, min, max, radius, radius,
+		func(Vector2) f64 { return radius },
+		func(p Vector2) bool { return polygonContains(polygon, p) },
+	)
+}
+
+// Points in rectangle `min`-`max` spaced by `radius` at each point.
+// `radius` must stay within `minRadius`-`maxRadius`, lower density means larger radius.
+func PoissonDiskVariable(s Source, min, max Vector2, minRadius, maxRadius f64, radius func(Vector2) f64) []Vector2 {
+	return poissonDisk(s, min, max, minRadius, maxRadius, radius,
+		func(p Vector2) bool {
+			return p[0] >= min[0] && p[1] >= min[1] && p[0] < max[0] && p[1] < max[1]
+		},
+	)
+}
+
+func poissonDisk(s Source, min, max Vector2, minRadius, maxRadius f64,
+	radius func(Vector2) f64, contains func(Vector2) bool,
+) []Vector2 {
+	PanicIf(minRadius <= 0 || maxRadius < minRadius,
+		"Poisson disk radius must be positive and min <= max",
+	)
+	// A cell holds at most one point
+	cell := minRadius / Sqrt2
+	size := max.Sub(min).Div1(cell).Floor().Add1(1)
+	width, height := int(size[0]), int(size[1])
+	grid := make([]int, width*height)
+	for i := range grid {
+		grid[i] = -1
+	}
+	cellOf := func(p Vector2) (int, int) {
+		c := p.Sub(min).Div1(cell)
+		return Clamp(int(c[0]), 0, width-1), Clamp(int(c[1]), 0, height-1)
+	}
+	reach := int(maxRadius/cell) + 1
+	var points []Vector2
+	var active []int
+	fits := func(p Vector2) bool {
+		if !contains(p) {
+			return false
+		}
+		r := radius(p)
+		cx, cy := cellOf(p)
+		for y := Max(cy-reach, 0); y <= Min(cy+reach, height-1); y++ {
+			for x := Max(cx-reach, 0); x <= Min(cx+reach, width-1); x++ {
+				if i := grid[y*width+x]; i >= 0 && points[i].Dst(p) < r {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	add := func(p Vector2) {
+		cx, cy := cellOf(p)
+		grid[cy*width+cx] = len(points)
+		active = append(active, len(points))
+		points = append(points, p)
+	}
+	// First point, the domain may not cover the whole rectangle
+	for i := 0; i < poissonAttempts*poissonAttempts && len(points) == 0; i++ {
+		p := Vec2(Lerp(min[0], max[0], RandFloat64(s)), Lerp(min[1], max[1], RandFloat64(s)))
+		if contains(p) {
+			add(p)
+		}
+	}
+	for len(active) > 0 {
+		a := RandIntN(s, len(active))
+		origin := points[active[a]]
+		r := radius(origin)
+		found := false
+		for i := 0; i < poissonAttempts && !found; i++ {
+			// Uniform in annulus r-2r
+			dst := Sqrt(r * r * (1 + 3*RandFloat64(s)))
+			p := Rad(Tau * RandFloat64(s)).Vec2().Mul1(dst).Add(origin)
+			if found = fits(p); found {
+				add(p)
+			}
+		}
+		if !found {
+			active[a] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+	return points
+}
+
+// Radical inverse of `index` in `base`, which must be at least 2.
+func Halton(index, base int) f64 {
+	PanicIf(base < 2, "Halton base must be at least 2")
+	result, fraction := 0., 1.
+	for ; index > 0; index /= base {
+		fraction /= f64(base)
+		result += fraction * f64(index%base)
+	}
+	return result
+}
+
+// First `n` points of the Halton sequence (bases 2, 3) in range [0,1).
+func HaltonPoints(n int) []Vector2 {
+	result := make([]Vector2, n)
+	for i := range result {
+		result[i] = Vec2(Halton(i+1, 2), Halton(i+1, 3))
+	}
+	return result
+}
+
+// First `n` points of the Sobol sequence in range [0,1).
+func SobolPoints(n int) []Vector2 {
+	// Direction numbers: van der Corput, then primitive polynomial x+1
+	var directions [2][32]u32
+	m := u32(1)
+	for k := range directions[0] {
+		directions[0][k] = 1 << (31 - k)
+		directions[1][k] = m << (31 - k)
+		m ^= m << 1
+	}
+	result := make([]Vector2, n)
+	var x, y u32
+	for i := range result {
+		result[i] = Vec2(f64(x)/(1<<32), f64(y)/(1<<32))
+		// Gray code order changes a single bit per step
+		c := bits.TrailingZeros(^uint(i))
+		x ^= directions[0][c]
+		y ^= directions[1][c]
+	}
+	return result
+}
+
+// First `n` points of the R2 sequence in range [0,1), see
+// http://extremelearning.com.au/unreasonable-effectiveness-of-quasirandom-sequences
+func R2Points(n int) []Vector2 {
+	step := Vec2(1/Plastic, 1/(Plastic*Plastic))
+	result := make([]Vector2, n)
+	for i := range result {
+		result[i] = step.Mul1(f64(i + 1)).Add1(.5).Wrap1(1)
+	}
+	return result
+}
+
+// Lowest and highest coordinates of `polygon`.
+func polygonBounds(polygon []Vector2) (min, max Vector2) {
+	min, max = polygon[0], polygon[0]
+	for _, p := range polygon[1:] {
+		min = Vec2(Min(min[0], p[0]), Min(min[1], p[1]))
+		max = Vec2(Max(max[0], p[0]), Max(max[1], p[1]))
+	}
+	return min, max
+}
+
+// Is `p` inside `polygon` (even-odd rule).
+func polygonContains(polygon []Vector2, p Vector2) bool {
+	inside := false
+	for i, a := range polygon {
+		b := polygon[(i+1)%len(polygon)]
+		if (a[1] > p[1]) != (b[1] > p[1]) &&
+			p[0] < (b[0]-a[0])*(p[1]-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,13 @@
+package gomisc
+
+import "testing"
+
+func TestHalton(t *testing.T) {
+	for i, want := range []f64{0, .5, .25, .75, .125} {
+		if got := Halton(i, 2); got != want {
+			t.Errorf("Halton(%v, 2) = %v, want %v", i, got, want)
+		}
+	}
+	testPanics(t, "Halton(1, 1)", func() { Halton(1, 1) })
+	testPanics(t, "Halton(1, 0)", func() { Halton(1, 0) })
+}
@@ -0,0 +1,58 @@
+package gomisc
+
+import (
+	cryptorand "crypto/rand"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Calls of SeedGen64 in this process, keeps seeds unique even if entropy repeats.
+var seedCounter atomic.Uint64
+
+// Deterministic seed sequence set by SeedOverride.
+type seedSequence struct {
+	base  u64
+	count atomic.Uint64
+}
+
+var seedOverride atomic.Pointer[seedSequence]
+
+// Generates a unique seed from crypto/rand.
+// Falls back to time, pid and hostname if crypto/rand fails.
+// After SeedOverride, generates its deterministic sequence instead.
+func SeedGen64() u64 {
+	if sequence := seedOverride.Load(); sequence != nil {
+		return mix64(sequence.base + sequence.count.Add(1)*0x9e3779b97f4a7c15)
+	}
+	count := mix64(seedCounter.Add(1))
+	var entropy [8]u8
+	if _, err := cryptorand.Read(entropy[:]); err == nil {
+		return U8sToU64(entropy[:]) ^ count
+	}
+	hostname, _ := os.Hostname()
+	return mix64(Hash64From(
+		u64(time.Now().UnixNano()), u64(os.Getpid()), Hash64String(hostname),
+	) ^ count)
+}
+
+// Makes SeedGen64, and every Init using it, reproducible from `seed`.
+// Meant for deterministic test runs.
+func SeedOverride(seed u64) {
+	seedOverride.Store(&seedSequence{base: seed})
+}
+
+// Undoes SeedOverride.
+func SeedOverrideReset() {
+	seedOverride.Store(nil)
+}
+
+// Seed from arbitrary bytes, equal bytes give equal seeds.
+func SeedFromBytes(data []u8) u64 {
+	return mix64(Hash64Bytes(data))
+}
+
+// Seed from a seed phrase, equal phrases give equal seeds.
+func SeedFromString(phrase string) u64 {
+	return SeedFromBytes([]u8(phrase))
+}
@@ -0,0 +1,150 @@
+package gomisc
+
+import "sort"
+
+// Uniform angle in range [0,Tau).
+func RandRad(s Source) Rad {
+	return Rad(Tau * RandFloat64(s))
+}
+
+// Uniform direction with 1 magnitude.
+func RandDir(s Source) Vector2 {
+	return RandRad(s).Vec2()
+}
+
+// Uniform point on the unit circle.
+func RandOnCircle(s Source) Vector2 {
+	return RandDir(s)
+}
+
+// Uniform point inside the unit disc.
+// Radius is square rooted, otherwise points clump in the center.
+func RandInDisc(s Source) Vector2 {
+	return RandDir(s).Mul1(Sqrt(RandFloat64(s)))
+}
+
+// Uniform point inside triangle `a`, `b`, `c`.
+func RandInTriangle(s Source, a, b, c Vector2) Vector2 {
+	u, v := RandFloat64(s), RandFloat64(s)
+	if u+v > 1 {
+		// Reflect back into the triangle half of the parallelogram
+		u, v = 1-u, 1-v
+	}
+	return b.Sub(a).Mul1(u).Add(c.Sub(a).Mul1(v)).Add(a)
+}
+
+// Uniform point inside `polygon`, convex or concave.
+// Triangulates on every call, see PolygonSampler for repeated draws.
+func RandInPolygon(s Source, polygon []Vector2) Vector2 {
+	return PolygonSamplerNew(polygon).Next(s)
+}
+
+// Uniform point on `polyline` by arc length.
+func RandOnPolyline(s Source, polyline []Vector2) Vector2 {
+	PanicIf(len(polyline) == 0, "Can't sample an empty polyline")
+	lengths := make([]f64, len(polyline))
+	for i := 1; i < len(polyline); i++ {
+		lengths[i] = lengths[i-1] + polyline[i].Dst(polyline[i-1])
+	}
+	target := RandFloat64(s) * lengths[len(lengths)-1]
+	i := sort.SearchFloat64s(lengths, target)
+	if i == 0 {
+		return polyline[0]
+	}
+	t := (target - lengths[i-1]) / (lengths[i] - lengths[i-1])
+	return polyline[i-1].Lerp(polyline[i], t)
+}
+
+// Precomputed area-weighted triangulation for uniform points inside a polygon.
+type PolygonSampler struct {
+	triangles []Triangle
+	areas     AliasTable
+}
+
+// Triangulates `polygon`, which must not self intersect.
+func PolygonSamplerNew(polygon []Vector2) PolygonSampler {
+	triangles := triangulate(polygon)
+	PanicIf(len(triangles) == 0, "Polygon has no area")
+	areas := make([]f64, len(triangles))
+	for i, t := range triangles {
+		areas[i] = t.Area()
+	}
+	return PolygonSampler{triangles, AliasTableNew(areas)}
+}
+
+// Uniform point inside the polygon.
+func (p PolygonSampler) Next(s Source) Vector2 {
+	t := p.triangles[p.areas.Next(s)]
+	return RandInTriangle(s, t[0], t[1], t[2])
+}
+
+// Ear clipping triangulation, skipping zero area triangles.
+func triangulate(polygon []Vector2) []Triangle {
+	if len(polygon) < 3 {
+		return nil
+	}
+	remaining := make([]int, len(polygon))
+	for i := range remaining {
+		remaining[i] = i
+	}
+	if signedArea(polygon) < 0 {
+		// Ears are convex in counter-clockwise order
+		for i, j := 0, len(remaining)-1; i < j; i, j = i+1, j-1 {
+			remaining[i], remaining[j] = remaining[j], remaining[i]
+		}
+	}
+	result := make([]Triangle, 0, len(polygon)-2)
+	for failed := 0; len(remaining) > 3 && failed < len(remaining); {
+		n := len(remaining)
+		i := failed % n
+		a := polygon[remaining[(i+n-1)%n]]
+		b := polygon[remaining[i]]
+		c := polygon[remaining[(i+1)%n]]
+		cross := b.Sub(a).Cross(c.Sub(b))
+		if cross == 0 {
+			// Collinear or duplicate vertex contributes nothing
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			failed = 0
+			continue
+		}
+		if cross < 0 || !isEar(polygon, remaining, a, b, c) {
+			failed++
+			continue
+		}
+		result = append(result, Triangle{a, b, c})
+		remaining = append(remaining[:i], remaining[i+1:]...)
+		failed = 0
+	}
+	if len(remaining) == 3 {
+		t := Triangle{polygon[remaining[0]], polygon[remaining[1]], polygon[remaining[2]]}
+		if t[1].Sub(t[0]).Cross(t[2].Sub(t[0])) != 0 {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// No other remaining vertex lies inside triangle `a`, `b`, `c`.
+func isEar(polygon []Vector2, remaining []int, a, b, c Vector2) bool {
+	for _, i := range remaining {
+		p := polygon[i]
+		if p.Eq(a) || p.Eq(b) || p.Eq(c) {
+			continue
+		}
+		if b.Sub(a).Cross(p.Sub(a)) >= 0 &&
+			c.Sub(b).Cross(p.Sub(b)) >= 0 &&
+			a.Sub(c).Cross(p.Sub(c)) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Positive if `polygon` is counter-clockwise (shoelace formula).
+func signedArea(polygon []Vector2) f64 {
+	result := 0.
+	for i, a := range polygon {
+		result += a.Cross(polygon[(i+1)%len(polygon)])
+	}
+	return result / 2
+}
@@ -0,0 +1,289 @@
+package gomisc
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+// Raw bits every random generator supplies.
+// Ranges, floats and distributions are package functions over it.
+type Source interface {
+	// Generates a random uint32 number
+	Next() u32
+	// Generates a random uint64 number
+	Next64() u64
+}
+
+var (
+	_ Source = (*MCG32)(nil)
+	_ Source = (*PCG32Fast)(nil)
+	_ Source = (*PCG32)(nil)
+	_ Source = (*PCG32Stream)(nil)
+	_ Source = (*PCG64)(nil)
+	_ Source = (*Xoshiro256SS)(nil)
+	_ Source = (*Xoshiro256P)(nil)
+	_ Source = (*SplitMix64)(nil)
+	_ Source = (*ChaCha)(nil)
+	_ Source = (*CryptoRand)(nil)
+	_ Source = (*Locked)(nil)
+	_ Source = (*AtomicMCG32)(nil)
+	_ Source = (*AtomicPCG32Fast)(nil)
+	_ Source = (*AtomicPCG32)(nil)
+	_ Source = (*Pool[PCG32, *PCG32])(nil)
+	_ Source = (*StdRand)(nil)
+)
+
+// Source that can be reseeded.
+type Seeder interface {
+	Seed(seed u64)
+}
+
+// Adapts `s` to math/rand Source64, usable with rand.New.
+// Seed panics unless `s` is a Seeder.
+func ToStd(s Source) rand.Source64 {
+	return stdSource{s}
+}
+
+type stdSource struct {
+	src Source
+}
+
+func (s stdSource) Uint64() u64 {
+	return s.src.Next64()
+}
+
+func (s stdSource) Int63() s64 {
+	return s64(s.Uint64() >> 1)
+}
+
+func (s stdSource) Seed(seed s64) {
+	seeder, ok := s.src.(Seeder)
+	PanicIf(!ok, "Source can't be reseeded")
+	seeder.Seed(u64(seed))
+}
+
+// Adapts `s` to math/rand/v2 Source, usable with rand.New.
+func ToStdV2(s Source) randv2.Source {
+	return stdSource{s}
+}
+
+// Source backed by a standard library generator.
+type StdRand struct {
+	src randv2.Source
+}
+
+// Wraps math/rand Source.
+func StdRandFrom(src rand.Source) *StdRand {
+	if src64, ok := src.(rand.Source64); ok {
+		return &StdRand{src64}
+	}
+	return &StdRand{stdV1Source{src}}
+}
+
+// Wraps math/rand/v2 Source.
+func StdRandFromV2(src randv2.Source) *StdRand {
+	return &StdRand{src}
+}
+
+type stdV1Source struct {
+	src rand.Source
+}
+
+func (s stdV1Source) Uint64() u64 {
+	return u64(s.src.Int63())>>31 | u64(s.src.Int63())<<32
+}
+
+// Generates a random uint32 number
+func (s *StdRand) Next() u32 {
+	return u32(s.src.Uint64() >> 32)
+}
+
+// Generates a random uint64 number
+func (s *StdRand) Next64() u64 {
+	return s.src.Uint64()
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func (s *StdRand) Range(n int) int {
+	return RandRange(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *StdRand) Uint32N(n u32) u32 {
+	return RandUint32N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *StdRand) Uint64N(n u64) u64 {
+	return RandUint64N(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *StdRand) IntN(n int) int {
+	return RandIntN(s, n)
+}
+
+// Generates unbiased number in range [0,n)
+func (s *StdRand) Int64N(n s64) s64 {
+	return RandInt64N(s, n)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *StdRand) IntRange(min, max int) int {
+	return RandIntRange(s, min, max)
+}
+
+// Generates unbiased number in range [min,max)
+func (s *StdRand) Int64Range(min, max s64) s64 {
+	return RandInt64Range(s, min, max)
+}
+
+// Generates number in range [0,1]
+func (s *StdRand) Normal32() f32 {
+	return RandNormal32(s)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func (s *StdRand) Normal64() f64 {
+	return RandNormal64(s)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func (s *StdRand) Float32() f32 {
+	return RandFloat32(s)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func (s *StdRand) Float64() f64 {
+	return RandFloat64(s)
+}
+
+// Generates number in range (0,1)
+func (s *StdRand) Float32Open() f32 {
+	return RandFloat32Open(s)
+}
+
+// Generates number in range (0,1)
+func (s *StdRand) Float64Open() f64 {
+	return RandFloat64Open(s)
+}
+
+// Generates number in range [0,1]
+func (s *StdRand) Float32Closed() f32 {
+	return RandFloat32Closed(s)
+}
+
+// Generates number in range [0,1]
+func (s *StdRand) Float64Closed() f64 {
+	return RandFloat64Closed(s)
+}
+
+// Generates number in range [0,n)
+// The larger the n the larger the bias (in general)
+// Usualy in practice it is insignificant
+func RandRange(s Source, n int) int {
+	PanicIf(n <= 0, "Range bound must be positive")
+	if u64(n) <= math.MaxUint32 {
+		return int(s.Next() % u32(n))
+	}
+	return int(s.Next64() % u64(n))
+}
+
+// Generates unbiased number in range [0,n)
+// Lemire's multiply-and-reject, see https://arxiv.org/abs/1805.10941
+func RandUint32N(s Source, n u32) u32 {
+	PanicIf(n == 0, "Range bound must be positive")
+	m := u64(s.Next()) * u64(n)
+	if low := u32(m); low < n {
+		threshold := -n % n
+		for low < threshold {
+			m = u64(s.Next()) * u64(n)
+			low = u32(m)
+		}
+	}
+	return u32(m >> 32)
+}
+
+// Generates unbiased number in range [0,n)
+// Lemire's multiply-and-reject, see https://arxiv.org/abs/1805.10941
+func RandUint64N(s Source, n u64) u64 {
+	if n <= math.MaxUint32 {
+		return u64(RandUint32N(s, u32(n)))
+	}
+	high, low := bits.Mul64(s.Next64(), n)
+	if low < n {
+		threshold := -n % n
+		for low < threshold {
+			high, low = bits.Mul64(s.Next64(), n)
+		}
+	}
+	return high
+}
+
+// Generates unbiased number in range [0,n)
+func RandIntN(s Source, n int) int {
+	PanicIf(n <= 0, "Range bound must be positive")
+	return int(RandUint64N(s, u64(n)))
+}
+
+// Generates unbiased number in range [0,n)
+func RandInt64N(s Source, n s64) s64 {
+	PanicIf(n <= 0, "Range bound must be positive")
+	return s64(RandUint64N(s, u64(n)))
+}
+
+// Generates unbiased number in range [min,max)
+func RandIntRange(s Source, min, max int) int {
+	PanicIf(min >= max, "Range min must be lower than max")
+	return min + int(RandUint64N(s, u64(max)-u64(min)))
+}
+
+// Generates unbiased number in range [min,max)
+func RandInt64Range(s Source, min, max s64) s64 {
+	PanicIf(min >= max, "Range min must be lower than max")
+	return min + s64(RandUint64N(s, u64(max)-u64(min)))
+}
+
+// Generates number in range [0,1]
+func RandNormal32(s Source) f32 {
+	return f32(s.Next()) / f32(1<<32-1)
+}
+
+// Generates number in range [0,1]. Has 32bit resolution
+func RandNormal64(s Source) f64 {
+	return f64(s.Next()) / f64(1<<32-1)
+}
+
+// Generates number in range [0,1). Has 24bit resolution
+func RandFloat32(s Source) f32 {
+	return f32(s.Next()>>8) / (1 << 24)
+}
+
+// Generates number in range [0,1). Has 53bit resolution
+func RandFloat64(s Source) f64 {
+	return f64(s.Next64()>>11) / (1 << 53)
+}
+
+// Generates number in range (0,1)
+func RandFloat32Open(s Source) f32 {
+	return (f32(s.Next()>>9) + .5) / (1 << 23)
+}
+
+// Generates number in range (0,1)
+func RandFloat64Open(s Source) f64 {
+	return (f64(s.Next64()>>12) + .5) / (1 << 52)
+}
+
+// Generates number in range [0,1]
+func RandFloat32Closed(s Source) f32 {
+	return f32(RandUint32N(s, 1<<24+1)) / (1 << 24)
+}
+
+// Generates number in range [0,1]
+func RandFloat64Closed(s Source) f64 {
+	return f64(RandUint64N(s, 1<<53+1)) / (1 << 53)
+}
@@ -0,0 +1,34 @@
+package gomisc
+
+import (
+	"io"
+	"log"
+	"os"
+	"testing"
+)
+
+// Fails unless `f` panics, keeping the PanicIf log line out of the output.
+func testPanics(t *testing.T, name string, f func()) {
+	t.Helper()
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s did not panic", name)
+		}
+	}()
+	f()
+}
+
+func TestRandRange(t *testing.T) {
+	s := PCG32New(1)
+	for _, n := range []int{1, 2, 5, 1 << 40} {
+		for i := 0; i < 100; i++ {
+			if v := s.Range(n); v < 0 || v >= n {
+				t.Fatalf("Range(%v) = %v", n, v)
+			}
+		}
+	}
+	testPanics(t, "Range(0)", func() { s.Range(0) })
+	testPanics(t, "Range(-5)", func() { s.Range(-5) })
+}
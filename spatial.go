@@ -0,0 +1,227 @@
+package gomisc
+
+// Handle of an entry in a spatial index, stable until the entry is removed.
+type SpatialID int
+
+// Entries with stable IDs, `L` is where the index keeps each entry.
+type spatialStore[T, L any] struct {
+	entries []spatialEntry[T, L]
+	free    []SpatialID
+	len     int
+}
+
+type spatialEntry[T, L any] struct {
+	pos      Vector2
+	value    T
+	location L
+	alive    bool
+}
+
+func (s *spatialStore[T, L]) add(pos Vector2, value T, location L) SpatialID {
+	s.len++
+	entry := spatialEntry[T, L]{pos, value, location, true}
+	if len(s.free) > 0 {
+		id := s.free[len(s.free)-1]
+		s.free = s.free[:len(s.free)-1]
+		s.entries[id] = entry
+		return id
+	}
+	s.entries = append(s.entries, entry)
+	return SpatialID(len(s.entries) - 1)
+}
+
+func (s *spatialStore[T, L]) remove(id SpatialID) {
+	s.at(id)
+	s.len--
+	s.entries[id] = spatialEntry[T, L]{}
+	s.free = append(s.free, id)
+}
+
+// Live entry `id`, panics if removed.
+func (s *spatialStore[T, L]) at(id SpatialID) *spatialEntry[T, L] {
+	PanicIf(id < 0 || int(id) >= len(s.entries) || !s.entries[id].alive, "Invalid SpatialID")
+	return &s.entries[id]
+}
+
+// Inserts `id` into `best`, the `k` closest so far ordered by distance.
+// Only `best[base:]` is touched, so results can be appended to a caller slice.
+func nearestPush(best []SpatialID, base, k int, id SpatialID, dstSq f64, dstSqOf func(SpatialID) f64) []SpatialID {
+	n := len(best) - base
+	if n == k {
+		if dstSq >= dstSqOf(best[len(best)-1]) {
+			return best
+		}
+		best = best[:len(best)-1]
+	}
+	i := len(best)
+	best = append(best, id)
+	for ; i > base && dstSqOf(best[i-1]) > dstSq; i-- {
+		best[i] = best[i-1]
+	}
+	best[i] = id
+	return best
+}
+
+// Squared distance beyond which nothing can enter `best[base:]`.
+func nearestBound(best []SpatialID, base, k int, dstSqOf func(SpatialID) f64) f64 {
+	if len(best)-base < k {
+		return Inf
+	}
+	return dstSqOf(best[len(best)-1])
+}
+
+// Uniform grid of square cells for entries spread over an unbounded area.
+// Best when queries are about the cell size and density is even.
+type SpatialHash[T any] struct {
+	store    spatialStore[T, Vector2i]
+	cells    map[Vector2i][]SpatialID
+	cellSize f64
+	// Cells ever occupied, bounds the nearest neighbour search
+	minCell, maxCell Vector2i
+}
+
+// Empty grid with `cellSize` sided cells, about the typical query radius.
+func SpatialHashNew[T any](cellSize f64) SpatialHash[T] {
+	PanicIf(cellSize <= 0, "Cell size must be positive")
+	return SpatialHash[T]{cells: map[Vector2i][]SpatialID{}, cellSize: cellSize}
+}
+
+func (h *SpatialHash[T]) cellOf(p Vector2) Vector2i {
+	return p.Div1(h.cellSize).FloorI()
+}
+
+func (h *SpatialHash[T]) link(id SpatialID, cell Vector2i) {
+	if len(h.cells) == 0 && h.store.len == 1 {
+		h.minCell, h.maxCell = cell, cell
+	}
+	h.minCell = Vec2i(Min(h.minCell[0], cell[0]), Min(h.minCell[1], cell[1]))
+	h.maxCell = Vec2i(Max(h.maxCell[0], cell[0]), Max(h.maxCell[1], cell[1]))
+	h.cells[cell] = append(h.cells[cell], id)
+}
+
+func (h *SpatialHash[T]) unlink(id SpatialID, cell Vector2i) {
+	ids := h.cells[cell]
+	for i, other := range ids {
+		if other == id {
+			ids[i] = ids[len(ids)-1]
+			ids = ids[:len(ids)-1]
+			break
+		}
+	}
+	if len(ids) == 0 {
+		delete(h.cells, cell)
+		return
+	}
+	h.cells[cell] = ids
+}
+
+// Entries in `h`.
+func (h *SpatialHash[T]) Len() int {
+	return h.store.len
+}
+
+// Adds `value` at `pos`.
+func (h *SpatialHash[T]) Insert(pos Vector2, value T) SpatialID {
+	cell := h.cellOf(pos)
+	id := h.store.add(pos, value, cell)
+	h.link(id, cell)
+	return id
+}
+
+// Removes entry `id`, the ID may be reused.
+func (h *SpatialHash[T]) Remove(id SpatialID) {
+	h.unlink(id, h.store.at(id).location)
+	h.store.remove(id)
+}
+
+// Changes the position of entry `id`.
+func (h *SpatialHash[T]) Move(id SpatialID, pos Vector2) {
+	entry := h.store.at(id)
+	entry.pos = pos
+	if cell := h.cellOf(pos); !cell.Eq(entry.location) {
+		h.unlink(id, entry.location)
+		entry.location = cell
+		h.link(id, cell)
+	}
+}
+
+// Position and value of entry `id`.
+func (h *SpatialHash[T]) Get(id SpatialID) (Vector2, T) {
+	entry := h.store.at(id)
+	return entry.pos, entry.value
+}
+
+// Calls `f` for each entry in `rect` until it returns false.
+func (h *SpatialHash[T]) Rect(rect Rect, f func(id SpatialID, pos Vector2, value T) bool) {
+	lo, hi := h.cellOf(rect.Min), h.cellOf(rect.Max)
+	if f64(hi[0]-lo[0]+1)*f64(hi[1]-lo[1]+1) > f64(len(h.cells)) {
+		// Mostly empty cells, cheaper to visit the occupied ones
+		for _, ids := range h.cells {
+			if !h.visit(ids, rect, f) {
+				return
+			}
+		}
+		return
+	}
+	for y := lo[1]; y <= hi[1]; y++ {
+		for x := lo[0]; x <= hi[0]; x++ {
+			if !h.visit(h.cells[Vec2i(x, y)], rect, f) {
+				return
+			}
+		}
+	}
+}
+
+// Calls `f` for `ids` in `rect`, false once `f` returns false.
+func (h *SpatialHash[T]) visit(ids []SpatialID, rect Rect, f func(id SpatialID, pos Vector2, value T) bool) bool {
+	for _, id := range ids {
+		entry := &h.store.entries[id]
+		if rect.Contains(entry.pos) && !f(id, entry.pos, entry.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Calls `f` for each entry within `radius` of `center` until it returns false.
+func (h *SpatialHash[T]) Radius(center Vector2, radius f64, f func(id SpatialID, pos Vector2, value T) bool) {
+	rSq := radius * radius
+	h.Rect(RectCentered(center, Vec2(radius, radius).Mul1(2)), func(id SpatialID, pos Vector2, value T) bool {
+		return pos.Sub(center).MagSq() > rSq || f(id, pos, value)
+	})
+}
+
+// Appends up to `k` entries closest to `center` to `dst`, nearest first.
+func (h *SpatialHash[T]) Nearest(center Vector2, k int, dst []SpatialID) []SpatialID {
+	base := len(dst)
+	if k <= 0 || h.store.len == 0 {
+		return dst
+	}
+	dstSqOf := func(id SpatialID) f64 {
+		return h.store.entries[id].pos.Sub(center).MagSq()
+	}
+	c := h.cellOf(center)
+	// Rings of cells around `c` until the ring can't hold anything closer
+	reach := Max(Max(c[0]-h.minCell[0], h.maxCell[0]-c[0]), Max(c[1]-h.minCell[1], h.maxCell[1]-c[1]))
+	for ring := 0; ring <= reach; ring++ {
+		if ring > 0 {
+			gap := f64(ring-1) * h.cellSize
+			if gap*gap >= nearestBound(dst, base, k, dstSqOf) {
+				break
+			}
+		}
+		for y := c[1] - ring; y <= c[1]+ring; y++ {
+			step := 1
+			if y != c[1]-ring && y != c[1]+ring {
+				// Only the left and right columns are new
+				step = Max(2*ring, 1)
+			}
+			for x := c[0] - ring; x <= c[0]+ring; x += step {
+				for _, id := range h.cells[Vec2i(x, y)] {
+					dst = nearestPush(dst, base, k, id, dstSqOf(id), dstSqOf)
+				}
+			}
+		}
+	}
+	return dst
+}
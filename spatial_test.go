@@ -0,0 +1,319 @@
+package gomisc
+
+import (
+	"sort"
+	"testing"
+)
+
+// Spatial indexes against a linear scan for radius and nearest neighbour queries.
+
+const (
+	spatialBenchCount  = 10000
+	spatialBenchSize   = 1000.
+	spatialBenchRadius = 20.
+	spatialBenchK      = 8
+)
+
+type spatialIndex interface {
+	Radius(center Vector2, radius f64, f func(SpatialID, Vector2, int) bool)
+	Nearest(center Vector2, k int, dst []SpatialID) []SpatialID
+}
+
+// Linear scan, the oracle the other indexes are tested against.
+type spatialBrute []Vector2
+
+func (b spatialBrute) Radius(center Vector2, radius f64, f func(SpatialID, Vector2, int) bool) {
+	for i, p := range b {
+		if p.Sub(center).MagSq() <= radius*radius && !f(SpatialID(i), p, i) {
+			return
+		}
+	}
+}
+
+func (b spatialBrute) Rect(rect Rect, f func(SpatialID, Vector2, int) bool) {
+	for i, p := range b {
+		if rect.Contains(p) && !f(SpatialID(i), p, i) {
+			return
+		}
+	}
+}
+
+func (b spatialBrute) Get(id SpatialID) (Vector2, int) {
+	return b[id], int(id)
+}
+
+func (b spatialBrute) Nearest(center Vector2, k int, dst []SpatialID) []SpatialID {
+	base := len(dst)
+	if k <= 0 {
+		return dst
+	}
+	for i, p := range b {
+		d := p.Dst(center)
+		if len(dst)-base == k && d >= b[dst[len(dst)-1]].Dst(center) {
+			continue
+		}
+		if len(dst)-base == k {
+			dst = dst[:len(dst)-1]
+		}
+		j := len(dst)
+		dst = append(dst, 0)
+		for ; j > base && b[dst[j-1]].Dst(center) > d; j-- {
+			dst[j] = dst[j-1]
+		}
+		dst[j] = SpatialID(i)
+	}
+	return dst
+}
+
+// Index with every query the oracle answers.
+type spatialQueryable interface {
+	spatialIndex
+	Rect(rect Rect, f func(SpatialID, Vector2, int) bool)
+	Get(id SpatialID) (Vector2, int)
+}
+
+// Index supporting Insert, Remove and Move.
+type spatialDynamic interface {
+	spatialQueryable
+	Len() int
+	Insert(pos Vector2, value int) SpatialID
+	Remove(id SpatialID)
+	Move(id SpatialID, pos Vector2)
+}
+
+// Index queries as sorted IDs, and distances of the nearest.
+type spatialResults struct {
+	radius, rect []SpatialID
+	nearest      []f64
+}
+
+// Runs the same queries on `index`, mapping its IDs through `ids` when not nil.
+func spatialQuery(index spatialQueryable, ids []SpatialID, center Vector2, radius f64, rect Rect, k int,
+) (r spatialResults) {
+	id := func(i SpatialID) SpatialID {
+		if ids == nil {
+			return i
+		}
+		return ids[i]
+	}
+	index.Radius(center, radius, func(i SpatialID, _ Vector2, _ int) bool {
+		r.radius = append(r.radius, id(i))
+		return true
+	})
+	index.Rect(rect, func(i SpatialID, _ Vector2, _ int) bool {
+		r.rect = append(r.rect, id(i))
+		return true
+	})
+	for _, list := range [][]SpatialID{r.radius, r.rect} {
+		sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	}
+	// Existing elements of dst must survive
+	nearest := index.Nearest(center, k, []SpatialID{-1})
+	for _, i := range nearest[1:] {
+		p, _ := index.Get(i)
+		r.nearest = append(r.nearest, p.Dst(center))
+	}
+	if nearest[0] != -1 {
+		r.nearest = nil
+	}
+	return r
+}
+
+// Fails unless `got` matches the oracle `want`.
+func testSpatialResults(t *testing.T, name string, got, want spatialResults) {
+	t.Helper()
+	if !sliceEqual(got.radius, want.radius) {
+		t.Fatalf("%s Radius = %v, want %v", name, got.radius, want.radius)
+	}
+	if !sliceEqual(got.rect, want.rect) {
+		t.Fatalf("%s Rect = %v, want %v", name, got.rect, want.rect)
+	}
+	if !sliceEqual(got.nearest, want.nearest) {
+		t.Fatalf("%s Nearest distances = %v, want %v", name, got.nearest, want.nearest)
+	}
+}
+
+func sliceEqual[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Random inserts, removals and moves, queries compared against spatialBrute.
+func TestSpatialDynamic(t *testing.T) {
+	const size = 100.
+	hash := SpatialHashNew[int](7)
+	quadtree := LooseQuadtreeNew[int](Rect{Max: Vec2(size, size)})
+	for _, x := range []struct {
+		name  string
+		index spatialDynamic
+	}{{"SpatialHash", &hash}, {"LooseQuadtree", &quadtree}} {
+		t.Run(x.name, func(t *testing.T) {
+			s := PCG32New(1)
+			// Mostly inside the bounds, some beyond them
+			point := func() Vector2 {
+				return Vec2(RandFloat64(&s)*size*1.2-size*.1, RandFloat64(&s)*size*1.2-size*.1)
+			}
+			live := map[SpatialID]Vector2{}
+			var ids []SpatialID
+			for op := 0; op < 4000; op++ {
+				switch r := RandIntN(&s, 10); {
+				case r < 5 || len(ids) == 0:
+					p := point()
+					if len(ids) > 0 && r == 0 {
+						// Coincident with another entry
+						p = live[ids[RandIntN(&s, len(ids))]]
+					}
+					id := x.index.Insert(p, op)
+					if _, ok := live[id]; ok {
+						t.Fatalf("Insert returned live ID %v", id)
+					}
+					live[id] = p
+					ids = append(ids, id)
+				case r < 7:
+					i := RandIntN(&s, len(ids))
+					x.index.Remove(ids[i])
+					delete(live, ids[i])
+					ids[i] = ids[len(ids)-1]
+					ids = ids[:len(ids)-1]
+				default:
+					id := ids[RandIntN(&s, len(ids))]
+					p := point()
+					if r == 7 {
+						// Small step, may stay in the same node
+						p = live[id].Add(Vec2(RandFloat64(&s)-.5, RandFloat64(&s)-.5))
+					}
+					x.index.Move(id, p)
+					live[id] = p
+				}
+				if x.index.Len() != len(live) {
+					t.Fatalf("Len = %v, want %v", x.index.Len(), len(live))
+				}
+				if op%20 != 0 {
+					continue
+				}
+				brute := make(spatialBrute, len(ids))
+				for i, id := range ids {
+					brute[i] = live[id]
+				}
+				center := point()
+				radius := RandFloat64(&s) * size / 4
+				rect := RectNew(point(), point())
+				k := RandIntN(&s, 12)
+				testSpatialResults(t, x.name,
+					spatialQuery(x.index, nil, center, radius, rect, k),
+					spatialQuery(brute, ids, center, radius, rect, k))
+			}
+		})
+	}
+}
+
+// KDTree queries compared against spatialBrute over the same points.
+func TestKDTreeRandom(t *testing.T) {
+	s := PCG32New(1)
+	for round := 0; round < 50; round++ {
+		points := make([]Vector2, RandIntN(&s, 300))
+		for i := range points {
+			points[i] = Vec2(RandFloat64(&s)*100, RandFloat64(&s)*100)
+			if i > 0 && RandIntN(&s, 10) == 0 {
+				points[i] = points[RandIntN(&s, i)]
+			}
+		}
+		tree := KDTreeNew[int](points, nil)
+		for q := 0; q < 20; q++ {
+			center := Vec2(RandFloat64(&s)*120-10, RandFloat64(&s)*120-10)
+			radius := RandFloat64(&s) * 30
+			rect := RectNew(Vec2(RandFloat64(&s)*100, RandFloat64(&s)*100),
+				Vec2(RandFloat64(&s)*100, RandFloat64(&s)*100))
+			k := RandIntN(&s, 12)
+			testSpatialResults(t, "KDTree",
+				spatialQuery(&tree, nil, center, radius, rect, k),
+				spatialQuery(spatialBrute(points), nil, center, radius, rect, k))
+		}
+	}
+}
+
+// Queries stop as soon as the callback returns false.
+func TestSpatialStop(t *testing.T) {
+	indexes, queries := spatialBenchSetup()
+	for _, x := range indexes {
+		calls := 0
+		x.index.Radius(queries[0], spatialBenchSize, func(SpatialID, Vector2, int) bool {
+			calls++
+			return false
+		})
+		if calls != 1 {
+			t.Errorf("%s Radius called back %d times after false", x.name, calls)
+		}
+	}
+}
+
+type spatialBenchIndex struct {
+	name  string
+	index spatialIndex
+}
+
+// Every index over the same random points, and random query centers.
+func spatialBenchSetup() ([]spatialBenchIndex, []Vector2) {
+	s := PCG32New(1)
+	point := func() Vector2 {
+		return Vec2(RandFloat64(&s)*spatialBenchSize, RandFloat64(&s)*spatialBenchSize)
+	}
+	points := make([]Vector2, spatialBenchCount)
+	values := make([]int, spatialBenchCount)
+	hash := SpatialHashNew[int](spatialBenchRadius)
+	quadtree := LooseQuadtreeNew[int](Rect{Max: Vec2(spatialBenchSize, spatialBenchSize)})
+	for i := range points {
+		points[i] = point()
+		values[i] = i
+		hash.Insert(points[i], i)
+		quadtree.Insert(points[i], i)
+	}
+	kdtree := KDTreeNew(points, values)
+	queries := make([]Vector2, 1024)
+	for i := range queries {
+		queries[i] = point()
+	}
+	return []spatialBenchIndex{
+		{"Brute", spatialBrute(points)},
+		{"SpatialHash", &hash},
+		{"LooseQuadtree", &quadtree},
+		{"KDTree", &kdtree},
+	}, queries
+}
+
+func BenchmarkSpatialRadius(b *testing.B) {
+	indexes, queries := spatialBenchSetup()
+	for _, x := range indexes {
+		b.Run(x.name, func(b *testing.B) {
+			b.ReportAllocs()
+			found := 0
+			visit := func(SpatialID, Vector2, int) bool {
+				found++
+				return true
+			}
+			for i := 0; i < b.N; i++ {
+				x.index.Radius(queries[i%len(queries)], spatialBenchRadius, visit)
+			}
+		})
+	}
+}
+
+func BenchmarkSpatialNearest(b *testing.B) {
+	indexes, queries := spatialBenchSetup()
+	for _, x := range indexes {
+		b.Run(x.name, func(b *testing.B) {
+			b.ReportAllocs()
+			dst := make([]SpatialID, 0, spatialBenchK)
+			for i := 0; i < b.N; i++ {
+				dst = x.index.Nearest(queries[i%len(queries)], spatialBenchK, dst[:0])
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+package gomisc
+
+import (
+	"encoding"
+	"encoding/hex"
+	"hash/crc32"
+)
+
+// Error.
+type StateInvalid string
+
+func (e StateInvalid) Error() string {
+	return string(e)
+}
+
+// Generator state layout: type tag, version, little-endian words, crc32 checksum.
+const stateVersion = 1
+
+const (
+	stateMCG32 u8 = iota + 1
+	statePCG32Fast
+	statePCG32
+	stateSplitMix64
+	stateXoshiro256SS
+	stateXoshiro256P
+	statePCG64
+	statePCG32Stream
+	stateChaCha
+)
+
+func stateMarshal(tag u8, words ...u64) []u8 {
+	result := []u8{tag, stateVersion}
+	for _, word := range words {
+		result = append(result, U64ToU8s(word)...)
+	}
+	return append(result, U32ToU8s(crc32.ChecksumIEEE(result))...)
+}
+
+func stateUnmarshal(data []u8, tag u8, words ...*u64) error {
+	if len(data) < 2+4 {
+		return StateInvalid("Generator state is too short")
+	}
+	body := data[:len(data)-4]
+	if crc32.ChecksumIEEE(body) != U8sToU32(data[len(body):]) {
+		return StateInvalid("Generator state is corrupted")
+	} else if body[0] != tag {
+		return StateInvalid("Generator state is of a different type")
+	} else if body[1] != stateVersion {
+		return StateInvalid("Generator state version is unsupported")
+	} else if len(body) != 2+8*len(words) {
+		return StateInvalid("Generator state has wrong length")
+	}
+	for i, word := range words {
+		*word = U8sToU64(body[2+8*i:])
+	}
+	return nil
+}
+
+// MarshalBinary of `s` as hex text.
+func stateMarshalText(s encoding.BinaryMarshaler) ([]u8, error) {
+	data, err := s.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return []u8(hex.EncodeToString(data)), nil
+}
+
+// UnmarshalBinary of `s` from hex text.
+func stateUnmarshalText(s encoding.BinaryUnmarshaler, text []u8) error {
+	data, err := hex.DecodeString(string(text))
+	if err != nil {
+		return StateInvalid("Generator state is not hex encoded")
+	}
+	return s.UnmarshalBinary(data)
+}
+
+// Encodes the state with type tag and version.
+func (s MCG32) MarshalBinary() ([]u8, error) {
+	return stateMarshal(stateMCG32, u64(s)), nil
+}
+
+// Restores the state, rejecting mismatched or corrupted data.
+func (s *MCG32) UnmarshalBinary(data []u8) error {
+	var state u64
+	if err := stateUnmarshal(data, stateMCG32, &state); err != nil {
+		return err
+	} else if state&1 == 0 {
+		return StateInvalid("MCG32 state must be odd")
+	}
+	*s = MCG32(state)
+	return nil
+}
+
+// Encodes the state as hex text.
+func (s MCG32) MarshalText() ([]u8, error) {
+	return stateMarshalText(s)
+}
+
+// Restores the state from hex text.
+func (s *MCG32) UnmarshalText(text []u8) error {
+	return stateUnmarshalText(s, text)
+}
+
+// Encodes the state with type tag and version.
+func (s PCG32Fast) MarshalBinary() ([]u8, error) {
+	return stateMarshal(statePCG32Fast, u64(s)), nil
+}
+
+// Restores the state, rejecting mismatched or corrupted data.
+func (s *PCG32Fast) UnmarshalBinary(data []u8) error {
+	var state u64
+	if err := stateUnmarshal(data, statePCG32Fast, &state); err != nil {
+		return err
+	} else if state&1 == 0 {
+		return StateInvalid("PCG32Fast state must be odd")
+	}
+	*s = PCG32Fast(state)
+	return nil
+}
+
+// Encodes the state as hex text.
+func (s PCG32Fast) MarshalText() ([]u8, error) {
+	return stateMarshalText(s)
+}
+
+// Restores the state from hex text.
+func (s *PCG32Fast) UnmarshalText(text []u8) error {
+	return stateUnmarshalText(s, text)
+}
+
+// Encodes the state with type tag and version.
+func (s PCG32) MarshalBinary() ([]u8, error) {
+	return stateMarshal(statePCG32, u64(s)), nil
+}
+
+// Restores the state, rejecting mismatched or corrupted data.
+func (s *PCG32) UnmarshalBinary(data []u8) error {
+	var state u64
+	if err := stateUnmarshal(data, statePCG32, &state); err != nil {
+		return err
+	}
+	*s = PCG32(state)
+	return nil
+}
+
+// Encodes the state as hex text.
+func (s PCG32) MarshalText() ([]u8, error) {
+	return stateMarshalText(s)
+}
+
+// Restores the state from hex text.
+func (s *PCG32) UnmarshalText(text []u8) error {
+	return stateUnmarshalText(s, text)
+}
+
+// Encodes the state with type tag and version.
+func (s PCG32Stream) MarshalBinary() ([]u8, error) {
+	return stateMarshal(statePCG32Stream, s.state, s.inc), nil
+}
+
+// Restores the state, rejecting mismatched or corrupted data.
+func (s *PCG32Stream) UnmarshalBinary(data []u8) error {
+	var state PCG32Stream
+	if err := stateUnmarshal(data, statePCG32Stream, &state.state, &state.inc); err != nil {
+		return err
+	} else if state.inc&1 == 0 {
+		return StateInvalid("PCG32Stream increment must be odd")
+	}
+	*s = state
+	return nil
+}
+
+// Encodes the state as hex text.
+func (s PCG32Stream) MarshalText() ([]u8, error) {
+	return stateMarshalText(s)
+}
+
+// Restores the state from hex text.
+func (s *PCG32Stream) UnmarshalText(text []u8) error {
+	return stateUnmarshalText(s, text)
+}
+
+// Encodes the state with type tag and version.
+func (s SplitMix64) MarshalBinary() ([]u8, error) {
+	return stateMarshal(stateSplitMix64, u64(s)), nil
+}
+
+// Restores the state, rejecting mismatched or corrupted data.
+func (s *SplitMix64) UnmarshalBinary(data []u8) error {
+	var state u64
+	if err := stateUnmarshal(data, stateSplitMix64, &state); err != nil {
+		return err
+	}
+	*s = SplitMix64(state)
+	return nil
+}
+
+// Encodes the state as hex text.
+func (s SplitMix64) MarshalText() ([]u8, error) {
+	return stateMarshalText(s)
+}
+
+// Restores the state from hex text.
+func (s *SplitMix64) UnmarshalText(text []u8) error {
+	return stateUnmarshalText(s, text)
+}
+
+// Encodes the state with type tag and version.
+func (s Xoshiro256SS) MarshalBinary() ([]u8, error) {
+	return stateMarshal(stateXoshiro256SS, s[0], s[1], s[2], s[3]), nil
+}
+
+// Restores the state, rejecting mismatched or corrupted data.
+func (s *Xoshiro256SS) UnmarshalBinary(data []u8) error {
+	var state Xoshiro256SS
+	if err := stateUnmarshal(data, stateXoshiro256SS, &state[0], &state[1], &state[2], &state[3]); err != nil {
+		return err
+	} else if state == (Xoshiro256SS{}) {
+		return StateInvalid("Xoshiro256SS state must not be all zeros")
+	}
+	*s = state
+	return nil
+}
+
+// Encodes the state as hex text.
+func (s Xoshiro256SS) MarshalText() ([]u8, error) {
+	return stateMarshalText(s)
+}
+
+// Restores the state from hex text.
+func (s *Xoshiro256SS) UnmarshalText(text []u8) error {
+	return stateUnmarshalText(s, text)
+}
+
+// Encodes the state with type tag and version.
+func (s Xoshiro256P) MarshalBinary() ([]u8, error) {
+	return stateMarshal(stateXoshiro256P, s[0], s[1], s[2], s[3]), nil
+}
+
+// Restores the state, rejecting mismatched or corrupted data.
+func (s *Xoshiro256P) UnmarshalBinary(data []u8) error {
+	var state Xoshiro256P
+	if err := stateUnmarshal(data, stateXoshiro256P, &state[0], &state[1], &state[2], &state[3]); err != nil {
+		return err
+	} else if state == (Xoshiro256P{}) {
+		return StateInvalid("Xoshiro256P state must not be all zeros")
+	}
+	*s = state
+	return nil
+}
+
+// Encodes the state as hex text.
+func (s Xoshiro256P) MarshalText() ([]u8, error) {
+	return stateMarshalText(s)
+}
+
+// Restores the state from hex text.
+func (s *Xoshiro256P) UnmarshalText(text []u8) error {
+	return stateUnmarshalText(s, text)
+}
+
+// Encodes the state with type tag and version.
+func (s PCG64) MarshalBinary() ([]u8, error) {
+	return stateMarshal(statePCG64, s.state.hi, s.state.lo, s.inc.hi, s.inc.lo), nil
+}
+
+// Restores the state, rejecting mismatched or corrupted data.
+func (s *PCG64) UnmarshalBinary(data []u8) error {
+	var state PCG64
+	if err := stateUnmarshal(data, statePCG64, &state.state.hi, &state.state.lo, &state.inc.hi, &state.inc.lo); err != nil {
+		return err
+	} else if state.inc.lo&1 == 0 {
+		return StateInvalid("PCG64 increment must be odd")
+	}
+	*s = state
+	return nil
+}
+
+// Encodes the state as hex text.
+func (s PCG64) MarshalText() ([]u8, error) {
+	return stateMarshalText(s)
+}
+
+// Restores the state from hex text.
+func (s *PCG64) UnmarshalText(text []u8) error {
+	return stateUnmarshalText(s, text)
+}
+
+// Encodes the key, position and rounds with type tag and version.
+// Anyone with the state can predict the output, keep it as secret as the key.
+func (s ChaCha) MarshalBinary() ([]u8, error) {
+	var words [7]u64
+	for i := 0; i < 4; i++ {
+		words[i] = u64(s.key[2*i]) | u64(s.key[2*i+1])<<32
+	}
+	words[4], words[5], words[6] = s.counter, u64(s.rounds), u64(s.left)
+	return stateMarshal(stateChaCha, words[:]...), nil
+}
+
+// Restores the state, rejecting mismatched or corrupted data.
+func (s *ChaCha) UnmarshalBinary(data []u8) error {
+	var words [7]u64
+	if err := stateUnmarshal(data, stateChaCha, &words[0], &words[1], &words[2], &words[3],
+		&words[4], &words[5], &words[6]); err != nil {
+		return err
+	}
+	counter, rounds, left := words[4], words[5], words[6]
+	if rounds%2 != 0 || rounds > 20 {
+		return StateInvalid("ChaCha rounds must be even and at most 20")
+	} else if left > 16 || left > 0 && (counter == 0 || rounds == 0) {
+		return StateInvalid("ChaCha position is out of range")
+	}
+	state := ChaCha{counter: counter, rounds: int(rounds), left: int(left)}
+	for i := 0; i < 4; i++ {
+		state.key[2*i], state.key[2*i+1] = u32(words[i]), u32(words[i]>>32)
+	}
+	if state.left > 0 {
+		chachaBlock(&state.block, &state.key, state.counter-1, state.rounds)
+	}
+	*s = state
+	return nil
+}
+
+// Encodes the state as hex text.
+func (s ChaCha) MarshalText() ([]u8, error) {
+	return stateMarshalText(s)
+}
+
+// Restores the state from hex text.
+func (s *ChaCha) UnmarshalText(text []u8) error {
+	return stateUnmarshalText(s, text)
+}
@@ -0,0 +1,111 @@
+package gomisc
+
+import (
+	"encoding"
+	"hash/crc32"
+	"testing"
+)
+
+type stateSource interface {
+	Source
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}
+
+// A seeded generator and an empty one of the same type.
+type stateCase struct {
+	name        string
+	seeded, new func() stateSource
+}
+
+// Every generator with saved state.
+func stateSources() []stateCase {
+	return []stateCase{
+		{"MCG32", func() stateSource { s := MCG32New(1); return &s }, func() stateSource { return new(MCG32) }},
+		{"PCG32Fast", func() stateSource { s := PCG32FastNew(1); return &s }, func() stateSource { return new(PCG32Fast) }},
+		{"PCG32", func() stateSource { s := PCG32New(1); return &s }, func() stateSource { return new(PCG32) }},
+		{"PCG32Stream", func() stateSource { s := PCG32NewStream(1, 2); return &s }, func() stateSource { return new(PCG32Stream) }},
+		{"SplitMix64", func() stateSource { s := SplitMix64New(1); return &s }, func() stateSource { return new(SplitMix64) }},
+		{"Xoshiro256SS", func() stateSource { s := Xoshiro256SSNew(1); return &s }, func() stateSource { return new(Xoshiro256SS) }},
+		{"Xoshiro256P", func() stateSource { s := Xoshiro256PNew(1); return &s }, func() stateSource { return new(Xoshiro256P) }},
+		{"PCG64", func() stateSource { s := PCG64New(1); return &s }, func() stateSource { return new(PCG64) }},
+		{"ChaCha8", func() stateSource { s := ChaCha8New([32]u8{1}); return &s }, func() stateSource { return new(ChaCha) }},
+	}
+}
+
+// Fails unless `restored` continues the sequence of `s`.
+func testStateContinues(t *testing.T, s, restored Source) {
+	t.Helper()
+	for i := 0; i < 8; i++ {
+		if want, got := s.Next64(), restored.Next64(); got != want {
+			t.Fatalf("output %d after restore = %#x, want %#x", i, got, want)
+		}
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	for _, g := range stateSources() {
+		t.Run(g.name, func(t *testing.T) {
+			s := g.seeded()
+			s.Next64()
+			data, err := s.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			text, err := s.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			fromData, fromText := g.new(), g.new()
+			if err := fromData.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if err := fromText.UnmarshalText(text); err != nil {
+				t.Fatal(err)
+			}
+			reference := g.seeded()
+			reference.Next64()
+			testStateContinues(t, s, fromData)
+			testStateContinues(t, reference, fromText)
+		})
+	}
+}
+
+// Fails unless unmarshaling `data` gives `want`.
+func testStateRejects(t *testing.T, s stateSource, data []u8, want StateInvalid) {
+	t.Helper()
+	if err := s.UnmarshalBinary(data); err != want {
+		t.Errorf("error = %v, want %v", err, want)
+	}
+}
+
+// `data` with its checksum recomputed.
+func stateResigned(data []u8) []u8 {
+	body := data[:len(data)-4]
+	return append(body[:len(body):len(body)], U32ToU8s(crc32.ChecksumIEEE(body))...)
+}
+
+func TestStateInvalid(t *testing.T) {
+	for _, g := range stateSources() {
+		t.Run(g.name, func(t *testing.T) {
+			data, _ := g.seeded().MarshalBinary()
+			version := append([]u8(nil), data...)
+			version[1]++
+			testStateRejects(t, g.new(), stateResigned(version), "Generator state version is unsupported")
+			corrupted := append([]u8(nil), data...)
+			corrupted[2] ^= 1
+			testStateRejects(t, g.new(), corrupted, "Generator state is corrupted")
+			tag := append([]u8(nil), data...)
+			tag[0] = 0
+			testStateRejects(t, g.new(), stateResigned(tag), "Generator state is of a different type")
+			long := append(append([]u8(nil), data[:len(data)-4]...), 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+			testStateRejects(t, g.new(), stateResigned(long), "Generator state has wrong length")
+			testStateRejects(t, g.new(), data[:3], "Generator state is too short")
+			if err := g.new().UnmarshalText([]u8("zz")); err != StateInvalid("Generator state is not hex encoded") {
+				t.Errorf("text error = %v", err)
+			}
+		})
+	}
+}
@@ -137,6 +137,11 @@ func (v Vector2) Dot(other Vector2) f64 {
 	return v.Mul(other).Sum()
 }
 
+// `v` and `other` cross product, z of the 3D cross product.
+func (v Vector2) Cross(other Vector2) f64 {
+	return v[0]*other[1] - v[1]*other[0]
+}
+
 // Magnitude squared.
 func (v Vector2) MagSq() f64 {
 	return v.Dot(v)
@@ -215,3 +220,18 @@ func (v Vector2) Rot(amount Rad) Vector2 {
 	newX := amount.Vec2()
 	return newX.Rot90().Mul1(v[1]).Add(newX.Mul1(v[0]))
 }
+
+// Convert elements to f32.
+func (v Vector2) F32() Vector2F32 {
+	return Vec2F32(f32(v[0]), f32(v[1]))
+}
+
+// Floor `v` elements into ints.
+func (v Vector2) FloorI() Vector2i {
+	return Vec2i(FloorI(v[0]), FloorI(v[1]))
+}
+
+// Round `v` elements into ints.
+func (v Vector2) RoundI() Vector2i {
+	return Vec2i(RoundI(v[0]), RoundI(v[1]))
+}
@@ -0,0 +1,113 @@
+package gomisc
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Error.
+type Vector2Invalid string
+
+func (e Vector2Invalid) Error() string {
+	return string(e)
+}
+
+// Vector2 encoded in JSON as {"x":1,"y":2} instead of [1,2].
+// Decoding accepts both forms either way.
+type Vector2Object Vector2
+
+// Pointers tell missing keys apart from zeros.
+type vector2Object struct {
+	X *f64 `json:"x"`
+	Y *f64 `json:"y"`
+}
+
+// Like "(1, 2.5)", exact enough to parse back.
+func (v Vector2) String() string {
+	return "(" + strconv.FormatFloat(v[0], 'g', -1, 64) + ", " +
+		strconv.FormatFloat(v[1], 'g', -1, 64) + ")"
+}
+
+// Like "(1, 2.5)".
+func (v Vector2) MarshalText() ([]u8, error) {
+	return []u8(v.String()), nil
+}
+
+// Parses "(x, y)", spaces optional.
+func (v *Vector2) UnmarshalText(text []u8) error {
+	s := strings.TrimSpace(string(text))
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return Vector2Invalid("Vector2 must be in parentheses")
+	}
+	parts := strings.Split(s[1:len(s)-1], ",")
+	if len(parts) != 2 {
+		return Vector2Invalid("Vector2 must have 2 comma separated elements")
+	}
+	var result Vector2
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return Vector2Invalid("Vector2 element is not a number")
+		}
+		result[i] = value
+	}
+	*v = result
+	return nil
+}
+
+// [x,y].
+// JSON methods spell []byte, go vet rejects the u8 alias in their signatures.
+func (v Vector2) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]f64(v))
+}
+
+// Either [x,y] or {"x":x,"y":y}, null leaves `v` unchanged.
+func (v *Vector2) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, "{") {
+		var object vector2Object
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		} else if object.X == nil || object.Y == nil {
+			return Vector2Invalid("Vector2 JSON object must have both x and y")
+		}
+		*v = Vec2(*object.X, *object.Y)
+		return nil
+	}
+	var elements []f64
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	} else if len(elements) != 2 {
+		return Vector2Invalid("Vector2 JSON array must have 2 elements")
+	}
+	*v = Vec2(elements[0], elements[1])
+	return nil
+}
+
+// {"x":x,"y":y}.
+func (v Vector2Object) MarshalJSON() ([]byte, error) {
+	return json.Marshal(vector2Object{&v[0], &v[1]})
+}
+
+// Either [x,y] or {"x":x,"y":y}, like Vector2.
+func (v *Vector2Object) UnmarshalJSON(data []byte) error {
+	return (*Vector2)(v).UnmarshalJSON(data)
+}
+
+// x then y, each as 8 little-endian bytes.
+func (v Vector2) MarshalBinary() ([]u8, error) {
+	return append(F64ToU8s(v[0]), F64ToU8s(v[1])...), nil
+}
+
+// Reads the 16 bytes of MarshalBinary.
+func (v *Vector2) UnmarshalBinary(data []u8) error {
+	if len(data) != 16 {
+		return Vector2Invalid("Vector2 binary must be 16 bytes")
+	}
+	*v = Vec2(U8sToF64(data), U8sToF64(data[8:]))
+	return nil
+}
@@ -0,0 +1,222 @@
+package gomisc
+
+type Vector2F32 [2]f32
+
+// New Vector2F32.
+func Vec2F32(x, y f32) Vector2F32 {
+	return Vector2F32{x, y}
+}
+
+// Are `v` and `other` identical.
+func (v Vector2F32) Eq(other Vector2F32) bool {
+	return v[0] == other[0] && v[1] == other[1]
+}
+
+// Changes sign of each `v` element.
+func (v Vector2F32) Neg() Vector2F32 {
+	return Vec2F32(-v[0], -v[1])
+}
+
+// Reciprocates each `v` element.
+func (v Vector2F32) Rcp() Vector2F32 {
+	return Vec2F32(1/v[0], 1/v[1])
+}
+
+// `v` and `other` pairwise add.
+func (v Vector2F32) Add(other Vector2F32) Vector2F32 {
+	return Vec2F32(v[0]+other[0], v[1]+other[1])
+}
+
+// Add `other` to each `v` element.
+func (v Vector2F32) Add1(other f32) Vector2F32 {
+	return Vec2F32(v[0]+other, v[1]+other)
+}
+
+// `v` and `other` pairwise subtract.
+func (v Vector2F32) Sub(other Vector2F32) Vector2F32 {
+	return Vec2F32(v[0]-other[0], v[1]-other[1])
+}
+
+// Subtract `other` from each `v` element.
+func (v Vector2F32) Sub1(other f32) Vector2F32 {
+	return Vec2F32(v[0]-other, v[1]-other)
+}
+
+// `v` and `other` pairwise multiply.
+func (v Vector2F32) Mul(other Vector2F32) Vector2F32 {
+	return Vec2F32(v[0]*other[0], v[1]*other[1])
+}
+
+// Multiply `other` with each `v` element.
+func (v Vector2F32) Mul1(other f32) Vector2F32 {
+	return Vec2F32(v[0]*other, v[1]*other)
+}
+
+// `v` and `other` pairwise divide.
+func (v Vector2F32) Div(other Vector2F32) Vector2F32 {
+	return Vec2F32(v[0]/other[0], v[1]/other[1])
+}
+
+// Divide `other` from each `v` element.
+func (v Vector2F32) Div1(other f32) Vector2F32 {
+	return Vec2F32(v[0]/other, v[1]/other)
+}
+
+// `v` and `other` pairwise wrap.
+func (v Vector2F32) Wrap(lens Vector2F32) Vector2F32 {
+	return Vec2F32(Wrap(v[0], lens[0]), Wrap(v[1], lens[1]))
+}
+
+// Wrap `len` to each `v` element.
+func (v Vector2F32) Wrap1(len f32) Vector2F32 {
+	return Vec2F32(Wrap(v[0], len), Wrap(v[1], len))
+}
+
+// Make `v` elements absolute.
+func (v Vector2F32) Abs() Vector2F32 {
+	return Vec2F32(Abs(v[0]), Abs(v[1]))
+}
+
+// Lowest `v` element.
+func (v Vector2F32) Min() f32 {
+	return Min(v[0], v[1])
+}
+
+// Highest `v` element.
+func (v Vector2F32) Max() f32 {
+	return Max(v[0], v[1])
+}
+
+// `v` element sum.
+func (v Vector2F32) Sum() f32 {
+	return v[0] + v[1]
+}
+
+// Sign `v` elements.
+func (v Vector2F32) Sign() Vector2F32 {
+	return Vec2F32(Sign(v[0]), Sign(v[1]))
+}
+
+// Floor `v` elements.
+func (v Vector2F32) Floor() Vector2F32 {
+	return Vec2F32(f32(Floor(f64(v[0]))), f32(Floor(f64(v[1]))))
+}
+
+// Round `v` elements.
+func (v Vector2F32) Round() Vector2F32 {
+	return Vec2F32(f32(Round(f64(v[0]))), f32(Round(f64(v[1]))))
+}
+
+// `v` and `other` linear interpolation.
+func (v Vector2F32) Lerp(other Vector2F32, t f32) Vector2F32 {
+	return other.Sub(v).Mul1(t).Add(v)
+}
+
+// `v` and `other` dot product.
+func (v Vector2F32) Dot(other Vector2F32) f32 {
+	return v.Mul(other).Sum()
+}
+
+// Magnitude squared.
+func (v Vector2F32) MagSq() f32 {
+	return v.Dot(v)
+}
+
+// Magnitude.
+func (v Vector2F32) Mag() f32 {
+	return f32(Sqrt(f64(v.MagSq())))
+}
+
+// `v` direction with `value` magnitude.
+func (v Vector2F32) MagSet(value f32) Vector2F32 {
+	if mag := v.Mag(); mag != 0 {
+		return v.Mul1(value / mag)
+	}
+	return Vector2F32{}
+}
+
+// `v` direction with 1 magnitude.
+func (v Vector2F32) Norm() Vector2F32 {
+	return v.MagSet(1)
+}
+
+// Clamps `v` magnitude.
+func (v Vector2F32) ClampMag(max f32) Vector2F32 {
+	if v.Mag() > max {
+		return v.MagSet(max)
+	}
+	return v
+}
+
+// Distance between `v` and `other`.
+func (v Vector2F32) Dst(other Vector2F32) f32 {
+	return v.Sub(other).Mag()
+}
+
+// Move `v` towards `other` by `dlt`.
+func (v Vector2F32) MoveTowards(other Vector2F32, dlt f32) Vector2F32 {
+	return other.Sub(v).MagSet(Min(dlt, v.Dst(other))).Add(v)
+}
+
+// Project `other` onto `v`, changing magnitude of `v`.
+// Both magnitudes affects result magnitude.
+func (v Vector2F32) Project(other Vector2F32) Vector2F32 {
+	return v.MagSet(v.Dot(other))
+}
+
+// Reflect `v` on normal `norm`.
+// `norm` should be normalized.
+func (v Vector2F32) Reflect(norm Vector2F32) Vector2F32 {
+	return v.Sub(norm.Mul1(v.Dot(norm) * 2))
+}
+
+// `v` and `other` cross product, z of the 3D cross product.
+func (v Vector2F32) Cross(other Vector2F32) f32 {
+	return v[0]*other[1] - v[1]*other[0]
+}
+
+// Swaps x and y
+func (v Vector2F32) ReverseOrder() Vector2F32 {
+	return Vec2F32(v[1], v[0])
+}
+
+// Rotate `v` 90 degrees.
+func (v Vector2F32) Rot90() Vector2F32 {
+	return Vec2F32(-v[1], v[0])
+}
+
+// Convert elements to f64.
+func (v Vector2F32) F64() Vector2 {
+	return Vec2(f64(v[0]), f64(v[1]))
+}
+
+// Angle to direction.
+func (a Rad) Vec2F32() Vector2F32 {
+	return Vec2F32(f32(a.Cos()), f32(a.Sin()))
+}
+
+// Direction to angle.
+func (v Vector2F32) Rad() Rad {
+	return Rad(Atan2(f64(v[1]), f64(v[0])))
+}
+
+// Angle from `v` to `other`.
+func (v Vector2F32) AngTo(other Vector2F32) Rad {
+	return other.Sub(v).Rad()
+}
+
+// Rotate `v` with angle `amount`.
+func (v Vector2F32) Rot(amount Rad) Vector2F32 {
+	newX := amount.Vec2F32()
+	return newX.Rot90().Mul1(v[1]).Add(newX.Mul1(v[0]))
+}
+
+// Floor `v` elements into ints.
+func (v Vector2F32) FloorI() Vector2i {
+	return Vec2i(FloorI(f64(v[0])), FloorI(f64(v[1])))
+}
+
+// Round `v` elements into ints.
+func (v Vector2F32) RoundI() Vector2i {
+	return Vec2i(RoundI(f64(v[0])), RoundI(f64(v[1])))
+}
@@ -0,0 +1,158 @@
+package gomisc
+
+type Vector2i [2]int
+
+// New Vector2i.
+func Vec2i(x, y int) Vector2i {
+	return Vector2i{x, y}
+}
+
+// Are `v` and `other` identical.
+func (v Vector2i) Eq(other Vector2i) bool {
+	return v[0] == other[0] && v[1] == other[1]
+}
+
+// Changes sign of each `v` element.
+func (v Vector2i) Neg() Vector2i {
+	return Vec2i(-v[0], -v[1])
+}
+
+// `v` and `other` pairwise add.
+func (v Vector2i) Add(other Vector2i) Vector2i {
+	return Vec2i(v[0]+other[0], v[1]+other[1])
+}
+
+// Add `other` to each `v` element.
+func (v Vector2i) Add1(other int) Vector2i {
+	return Vec2i(v[0]+other, v[1]+other)
+}
+
+// `v` and `other` pairwise subtract.
+func (v Vector2i) Sub(other Vector2i) Vector2i {
+	return Vec2i(v[0]-other[0], v[1]-other[1])
+}
+
+// Subtract `other` from each `v` element.
+func (v Vector2i) Sub1(other int) Vector2i {
+	return Vec2i(v[0]-other, v[1]-other)
+}
+
+// `v` and `other` pairwise multiply.
+func (v Vector2i) Mul(other Vector2i) Vector2i {
+	return Vec2i(v[0]*other[0], v[1]*other[1])
+}
+
+// Multiply `other` with each `v` element.
+func (v Vector2i) Mul1(other int) Vector2i {
+	return Vec2i(v[0]*other, v[1]*other)
+}
+
+// `v` and `other` pairwise divide.
+func (v Vector2i) Div(other Vector2i) Vector2i {
+	return Vec2i(v[0]/other[0], v[1]/other[1])
+}
+
+// Divide `other` from each `v` element.
+func (v Vector2i) Div1(other int) Vector2i {
+	return Vec2i(v[0]/other, v[1]/other)
+}
+
+// `v` and `other` pairwise wrap.
+func (v Vector2i) Wrap(lens Vector2i) Vector2i {
+	return Vec2i(Wrap(v[0], lens[0]), Wrap(v[1], lens[1]))
+}
+
+// Wrap `len` to each `v` element.
+func (v Vector2i) Wrap1(len int) Vector2i {
+	return Vec2i(Wrap(v[0], len), Wrap(v[1], len))
+}
+
+// Make `v` elements absolute.
+func (v Vector2i) Abs() Vector2i {
+	return Vec2i(Abs(v[0]), Abs(v[1]))
+}
+
+// Lowest `v` element.
+func (v Vector2i) Min() int {
+	return Min(v[0], v[1])
+}
+
+// Highest `v` element.
+func (v Vector2i) Max() int {
+	return Max(v[0], v[1])
+}
+
+// `v` element sum.
+func (v Vector2i) Sum() int {
+	return v[0] + v[1]
+}
+
+// Sign `v` elements.
+func (v Vector2i) Sign() Vector2i {
+	return Vec2i(Ternary(v[0] < 0, -1, 1), Ternary(v[1] < 0, -1, 1))
+}
+
+// `v` and `other` dot product.
+func (v Vector2i) Dot(other Vector2i) int {
+	return v.Mul(other).Sum()
+}
+
+// Magnitude squared.
+func (v Vector2i) MagSq() int {
+	return v.Dot(v)
+}
+
+// Magnitude.
+func (v Vector2i) Mag() f64 {
+	return Sqrt(f64(v.MagSq()))
+}
+
+// Distance between `v` and `other`.
+func (v Vector2i) Dst(other Vector2i) f64 {
+	return v.Sub(other).Mag()
+}
+
+// Grid distance between `v` and `other`, moving along one axis at a time.
+func (v Vector2i) DstManhattan(other Vector2i) int {
+	return v.Sub(other).Abs().Sum()
+}
+
+// Grid distance between `v` and `other`, moving diagonally as well.
+func (v Vector2i) DstChebyshev(other Vector2i) int {
+	return v.Sub(other).Abs().Max()
+}
+
+// `v` and `other` cross product, z of the 3D cross product.
+func (v Vector2i) Cross(other Vector2i) int {
+	return v[0]*other[1] - v[1]*other[0]
+}
+
+// Swaps x and y
+func (v Vector2i) ReverseOrder() Vector2i {
+	return Vec2i(v[1], v[0])
+}
+
+// Rotate `v` 90 degrees.
+func (v Vector2i) Rot90() Vector2i {
+	return Vec2i(-v[1], v[0])
+}
+
+// `v` and `other` linear interpolation, rounded to the nearest grid point.
+func (v Vector2i) Lerp(other Vector2i, t f64) Vector2i {
+	return v.F64().Lerp(other.F64(), t).RoundI()
+}
+
+// Move `v` towards `other` by `dlt`, rounded to the nearest grid point.
+func (v Vector2i) MoveTowards(other Vector2i, dlt f64) Vector2i {
+	return v.F64().MoveTowards(other.F64(), dlt).RoundI()
+}
+
+// Convert elements to f32.
+func (v Vector2i) F32() Vector2F32 {
+	return Vec2F32(f32(v[0]), f32(v[1]))
+}
+
+// Convert elements to f64.
+func (v Vector2i) F64() Vector2 {
+	return Vec2(f64(v[0]), f64(v[1]))
+}
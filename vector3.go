@@ -0,0 +1,223 @@
+package gomisc
+
+type Vector3 [3]f64
+
+// New Vector3.
+func Vec3(x, y, z f64) Vector3 {
+	return Vector3{x, y, z}
+}
+
+// Are `v` and `other` identical.
+func (v Vector3) Eq(other Vector3) bool {
+	return v[0] == other[0] && v[1] == other[1] && v[2] == other[2]
+}
+
+// Changes sign of each `v` element.
+func (v Vector3) Neg() Vector3 {
+	return Vec3(-v[0], -v[1], -v[2])
+}
+
+// Reciprocates each `v` element.
+func (v Vector3) Rcp() Vector3 {
+	return Vec3(1/v[0], 1/v[1], 1/v[2])
+}
+
+// `v` and `other` pairwise add.
+func (v Vector3) Add(other Vector3) Vector3 {
+	return Vec3(v[0]+other[0], v[1]+other[1], v[2]+other[2])
+}
+
+// Add `other` to each `v` element.
+func (v Vector3) Add1(other f64) Vector3 {
+	return Vec3(v[0]+other, v[1]+other, v[2]+other)
+}
+
+// `v` and `other` pairwise subtract.
+func (v Vector3) Sub(other Vector3) Vector3 {
+	return Vec3(v[0]-other[0], v[1]-other[1], v[2]-other[2])
+}
+
+// Subtract `other` from each `v` element.
+func (v Vector3) Sub1(other f64) Vector3 {
+	return Vec3(v[0]-other, v[1]-other, v[2]-other)
+}
+
+// `v` and `other` pairwise multiply.
+func (v Vector3) Mul(other Vector3) Vector3 {
+	return Vec3(v[0]*other[0], v[1]*other[1], v[2]*other[2])
+}
+
+// Multiply `other` with each `v` element.
+func (v Vector3) Mul1(other f64) Vector3 {
+	return Vec3(v[0]*other, v[1]*other, v[2]*other)
+}
+
+// `v` and `other` pairwise divide.
+func (v Vector3) Div(other Vector3) Vector3 {
+	return Vec3(v[0]/other[0], v[1]/other[1], v[2]/other[2])
+}
+
+// Divide `other` from each `v` element.
+func (v Vector3) Div1(other f64) Vector3 {
+	return Vec3(v[0]/other, v[1]/other, v[2]/other)
+}
+
+// `v` and `other` pairwise wrap.
+func (v Vector3) Wrap(lens Vector3) Vector3 {
+	return Vec3(Wrap(v[0], lens[0]), Wrap(v[1], lens[1]), Wrap(v[2], lens[2]))
+}
+
+// Wrap `len` to each `v` element.
+func (v Vector3) Wrap1(len f64) Vector3 {
+	return Vec3(Wrap(v[0], len), Wrap(v[1], len), Wrap(v[2], len))
+}
+
+// Make `v` elements absolute.
+func (v Vector3) Abs() Vector3 {
+	return Vec3(Abs(v[0]), Abs(v[1]), Abs(v[2]))
+}
+
+// Lowest `v` element.
+func (v Vector3) Min() f64 {
+	return Min(v[0], v[1], v[2])
+}
+
+// Highest `v` element.
+func (v Vector3) Max() f64 {
+	return Max(v[0], v[1], v[2])
+}
+
+// `v` element sum.
+func (v Vector3) Sum() f64 {
+	return v[0] + v[1] + v[2]
+}
+
+// Sign `v` elements.
+func (v Vector3) Sign() Vector3 {
+	return Vec3(Sign(v[0]), Sign(v[1]), Sign(v[2]))
+}
+
+// Floor `v` elements.
+func (v Vector3) Floor() Vector3 {
+	return Vec3(Floor(v[0]), Floor(v[1]), Floor(v[2]))
+}
+
+// Round `v` elements.
+func (v Vector3) Round() Vector3 {
+	return Vec3(Round(v[0]), Round(v[1]), Round(v[2]))
+}
+
+// `v` and `other` linear interpolation.
+func (v Vector3) Lerp(other Vector3, t f64) Vector3 {
+	return other.Sub(v).Mul1(t).Add(v)
+}
+
+// `v` and `other` dot product.
+func (v Vector3) Dot(other Vector3) f64 {
+	return v.Mul(other).Sum()
+}
+
+// Magnitude squared.
+func (v Vector3) MagSq() f64 {
+	return v.Dot(v)
+}
+
+// Magnitude.
+func (v Vector3) Mag() f64 {
+	return Sqrt(v.MagSq())
+}
+
+// `v` direction with `value` magnitude.
+func (v Vector3) MagSet(value f64) Vector3 {
+	if mag := v.Mag(); mag != 0 {
+		return v.Mul1(value / mag)
+	}
+	return Vector3{}
+}
+
+// `v` direction with 1 magnitude.
+func (v Vector3) Norm() Vector3 {
+	return v.MagSet(1)
+}
+
+// Clamps `v` magnitude.
+func (v Vector3) ClampMag(max f64) Vector3 {
+	if v.Mag() > max {
+		return v.MagSet(max)
+	}
+	return v
+}
+
+// Distance between `v` and `other`.
+func (v Vector3) Dst(other Vector3) f64 {
+	return v.Sub(other).Mag()
+}
+
+// Move `v` towards `other` by `dlt`.
+func (v Vector3) MoveTowards(other Vector3, dlt f64) Vector3 {
+	return other.Sub(v).MagSet(Min(dlt, v.Dst(other))).Add(v)
+}
+
+// Project `other` onto `v`, changing magnitude of `v`.
+// Both magnitudes affects result magnitude.
+func (v Vector3) Project(other Vector3) Vector3 {
+	return v.MagSet(v.Dot(other))
+}
+
+// Reflect `v` on normal `norm`.
+// `norm` should be normalized.
+func (v Vector3) Reflect(norm Vector3) Vector3 {
+	return v.Sub(norm.Mul1(v.Dot(norm) * 2))
+}
+
+// `v` and `other` cross product, perpendicular to both.
+func (v Vector3) Cross(other Vector3) Vector3 {
+	return Vec3(
+		v[1]*other[2]-v[2]*other[1],
+		v[2]*other[0]-v[0]*other[2],
+		v[0]*other[1]-v[1]*other[0],
+	)
+}
+
+// Rotate `v` around `axis` with angle `amount` (Rodrigues' formula).
+// `axis` should be normalized.
+func (v Vector3) Rotate(axis Vector3, amount Rad) Vector3 {
+	cos, sin := amount.Cos(), amount.Sin()
+	return v.Mul1(cos).
+		Add(axis.Cross(v).Mul1(sin)).
+		Add(axis.Mul1(axis.Dot(v) * (1 - cos)))
+}
+
+// New Vector3 from spherical coordinates.
+// `polar` is the angle from +z, `azimuth` the angle from +x around +z.
+func Vec3Spherical(mag f64, polar, azimuth Rad) Vector3 {
+	return Vec3(
+		mag*polar.Sin()*azimuth.Cos(),
+		mag*polar.Sin()*azimuth.Sin(),
+		mag*polar.Cos(),
+	)
+}
+
+// Spherical coordinates, see Vec3Spherical.
+func (v Vector3) Spherical() (mag f64, polar, azimuth Rad) {
+	mag = v.Mag()
+	if mag == 0 {
+		return 0, 0, 0
+	}
+	return mag, Rad(Acos(Clamp(v[2]/mag, -1, 1))), Rad(Atan2(v[1], v[0]))
+}
+
+// Convert elements to f32.
+func (v Vector3) F32() Vector3F32 {
+	return Vec3F32(f32(v[0]), f32(v[1]), f32(v[2]))
+}
+
+// Floor `v` elements into ints.
+func (v Vector3) FloorI() Vector3i {
+	return Vec3i(FloorI(v[0]), FloorI(v[1]), FloorI(v[2]))
+}
+
+// Round `v` elements into ints.
+func (v Vector3) RoundI() Vector3i {
+	return Vec3i(RoundI(v[0]), RoundI(v[1]), RoundI(v[2]))
+}
@@ -0,0 +1,215 @@
+package gomisc
+
+type Vector3F32 [3]f32
+
+// New Vector3F32.
+func Vec3F32(x, y, z f32) Vector3F32 {
+	return Vector3F32{x, y, z}
+}
+
+// Are `v` and `other` identical.
+func (v Vector3F32) Eq(other Vector3F32) bool {
+	return v[0] == other[0] && v[1] == other[1] && v[2] == other[2]
+}
+
+// Changes sign of each `v` element.
+func (v Vector3F32) Neg() Vector3F32 {
+	return Vec3F32(-v[0], -v[1], -v[2])
+}
+
+// Reciprocates each `v` element.
+func (v Vector3F32) Rcp() Vector3F32 {
+	return Vec3F32(1/v[0], 1/v[1], 1/v[2])
+}
+
+// `v` and `other` pairwise add.
+func (v Vector3F32) Add(other Vector3F32) Vector3F32 {
+	return Vec3F32(v[0]+other[0], v[1]+other[1], v[2]+other[2])
+}
+
+// Add `other` to each `v` element.
+func (v Vector3F32) Add1(other f32) Vector3F32 {
+	return Vec3F32(v[0]+other, v[1]+other, v[2]+other)
+}
+
+// `v` and `other` pairwise subtract.
+func (v Vector3F32) Sub(other Vector3F32) Vector3F32 {
+	return Vec3F32(v[0]-other[0], v[1]-other[1], v[2]-other[2])
+}
+
+// Subtract `other` from each `v` element.
+func (v Vector3F32) Sub1(other f32) Vector3F32 {
+	return Vec3F32(v[0]-other, v[1]-other, v[2]-other)
+}
+
+// `v` and `other` pairwise multiply.
+func (v Vector3F32) Mul(other Vector3F32) Vector3F32 {
+	return Vec3F32(v[0]*other[0], v[1]*other[1], v[2]*other[2])
+}
+
+// Multiply `other` with each `v` element.
+func (v Vector3F32) Mul1(other f32) Vector3F32 {
+	return Vec3F32(v[0]*other, v[1]*other, v[2]*other)
+}
+
+// `v` and `other` pairwise divide.
+func (v Vector3F32) Div(other Vector3F32) Vector3F32 {
+	return Vec3F32(v[0]/other[0], v[1]/other[1], v[2]/other[2])
+}
+
+// Divide `other` from each `v` element.
+func (v Vector3F32) Div1(other f32) Vector3F32 {
+	return Vec3F32(v[0]/other, v[1]/other, v[2]/other)
+}
+
+// `v` and `other` pairwise wrap.
+func (v Vector3F32) Wrap(lens Vector3F32) Vector3F32 {
+	return Vec3F32(Wrap(v[0], lens[0]), Wrap(v[1], lens[1]), Wrap(v[2], lens[2]))
+}
+
+// Wrap `len` to each `v` element.
+func (v Vector3F32) Wrap1(len f32) Vector3F32 {
+	return Vec3F32(Wrap(v[0], len), Wrap(v[1], len), Wrap(v[2], len))
+}
+
+// Make `v` elements absolute.
+func (v Vector3F32) Abs() Vector3F32 {
+	return Vec3F32(Abs(v[0]), Abs(v[1]), Abs(v[2]))
+}
+
+// Lowest `v` element.
+func (v Vector3F32) Min() f32 {
+	return Min(v[0], v[1], v[2])
+}
+
+// Highest `v` element.
+func (v Vector3F32) Max() f32 {
+	return Max(v[0], v[1], v[2])
+}
+
+// `v` element sum.
+func (v Vector3F32) Sum() f32 {
+	return v[0] + v[1] + v[2]
+}
+
+// Sign `v` elements.
+func (v Vector3F32) Sign() Vector3F32 {
+	return Vec3F32(Sign(v[0]), Sign(v[1]), Sign(v[2]))
+}
+
+// Floor `v` elements.
+func (v Vector3F32) Floor() Vector3F32 {
+	return Vec3F32(f32(Floor(f64(v[0]))), f32(Floor(f64(v[1]))), f32(Floor(f64(v[2]))))
+}
+
+// Round `v` elements.
+func (v Vector3F32) Round() Vector3F32 {
+	return Vec3F32(f32(Round(f64(v[0]))), f32(Round(f64(v[1]))), f32(Round(f64(v[2]))))
+}
+
+// `v` and `other` linear interpolation.
+func (v Vector3F32) Lerp(other Vector3F32, t f32) Vector3F32 {
+	return other.Sub(v).Mul1(t).Add(v)
+}
+
+// `v` and `other` dot product.
+func (v Vector3F32) Dot(other Vector3F32) f32 {
+	return v.Mul(other).Sum()
+}
+
+// Magnitude squared.
+func (v Vector3F32) MagSq() f32 {
+	return v.Dot(v)
+}
+
+// Magnitude.
+func (v Vector3F32) Mag() f32 {
+	return f32(Sqrt(f64(v.MagSq())))
+}
+
+// `v` direction with `value` magnitude.
+func (v Vector3F32) MagSet(value f32) Vector3F32 {
+	if mag := v.Mag(); mag != 0 {
+		return v.Mul1(value / mag)
+	}
+	return Vector3F32{}
+}
+
+// `v` direction with 1 magnitude.
+func (v Vector3F32) Norm() Vector3F32 {
+	return v.MagSet(1)
+}
+
+// Clamps `v` magnitude.
+func (v Vector3F32) ClampMag(max f32) Vector3F32 {
+	if v.Mag() > max {
+		return v.MagSet(max)
+	}
+	return v
+}
+
+// Distance between `v` and `other`.
+func (v Vector3F32) Dst(other Vector3F32) f32 {
+	return v.Sub(other).Mag()
+}
+
+// Move `v` towards `other` by `dlt`.
+func (v Vector3F32) MoveTowards(other Vector3F32, dlt f32) Vector3F32 {
+	return other.Sub(v).MagSet(Min(dlt, v.Dst(other))).Add(v)
+}
+
+// Project `other` onto `v`, changing magnitude of `v`.
+// Both magnitudes affects result magnitude.
+func (v Vector3F32) Project(other Vector3F32) Vector3F32 {
+	return v.MagSet(v.Dot(other))
+}
+
+// Reflect `v` on normal `norm`.
+// `norm` should be normalized.
+func (v Vector3F32) Reflect(norm Vector3F32) Vector3F32 {
+	return v.Sub(norm.Mul1(v.Dot(norm) * 2))
+}
+
+// `v` and `other` cross product, perpendicular to both.
+func (v Vector3F32) Cross(other Vector3F32) Vector3F32 {
+	return Vec3F32(
+		v[1]*other[2]-v[2]*other[1],
+		v[2]*other[0]-v[0]*other[2],
+		v[0]*other[1]-v[1]*other[0],
+	)
+}
+
+// Rotate `v` around `axis` with angle `amount` (Rodrigues' formula).
+// `axis` should be normalized.
+func (v Vector3F32) Rotate(axis Vector3F32, amount Rad) Vector3F32 {
+	cos, sin := f32(amount.Cos()), f32(amount.Sin())
+	return v.Mul1(cos).
+		Add(axis.Cross(v).Mul1(sin)).
+		Add(axis.Mul1(axis.Dot(v) * (1 - cos)))
+}
+
+// New Vector3F32 from spherical coordinates, see Vec3Spherical.
+func Vec3F32Spherical(mag f32, polar, azimuth Rad) Vector3F32 {
+	return Vec3Spherical(f64(mag), polar, azimuth).F32()
+}
+
+// Spherical coordinates, see Vec3Spherical.
+func (v Vector3F32) Spherical() (mag f32, polar, azimuth Rad) {
+	m, polar, azimuth := v.F64().Spherical()
+	return f32(m), polar, azimuth
+}
+
+// Convert elements to f64.
+func (v Vector3F32) F64() Vector3 {
+	return Vec3(f64(v[0]), f64(v[1]), f64(v[2]))
+}
+
+// Floor `v` elements into ints.
+func (v Vector3F32) FloorI() Vector3i {
+	return Vec3i(FloorI(f64(v[0])), FloorI(f64(v[1])), FloorI(f64(v[2])))
+}
+
+// Round `v` elements into ints.
+func (v Vector3F32) RoundI() Vector3i {
+	return Vec3i(RoundI(f64(v[0])), RoundI(f64(v[1])), RoundI(f64(v[2])))
+}
@@ -0,0 +1,152 @@
+package gomisc
+
+type Vector3i [3]int
+
+// New Vector3i.
+func Vec3i(x, y, z int) Vector3i {
+	return Vector3i{x, y, z}
+}
+
+// Are `v` and `other` identical.
+func (v Vector3i) Eq(other Vector3i) bool {
+	return v[0] == other[0] && v[1] == other[1] && v[2] == other[2]
+}
+
+// Changes sign of each `v` element.
+func (v Vector3i) Neg() Vector3i {
+	return Vec3i(-v[0], -v[1], -v[2])
+}
+
+// `v` and `other` pairwise add.
+func (v Vector3i) Add(other Vector3i) Vector3i {
+	return Vec3i(v[0]+other[0], v[1]+other[1], v[2]+other[2])
+}
+
+// Add `other` to each `v` element.
+func (v Vector3i) Add1(other int) Vector3i {
+	return Vec3i(v[0]+other, v[1]+other, v[2]+other)
+}
+
+// `v` and `other` pairwise subtract.
+func (v Vector3i) Sub(other Vector3i) Vector3i {
+	return Vec3i(v[0]-other[0], v[1]-other[1], v[2]-other[2])
+}
+
+// Subtract `other` from each `v` element.
+func (v Vector3i) Sub1(other int) Vector3i {
+	return Vec3i(v[0]-other, v[1]-other, v[2]-other)
+}
+
+// `v` and `other` pairwise multiply.
+func (v Vector3i) Mul(other Vector3i) Vector3i {
+	return Vec3i(v[0]*other[0], v[1]*other[1], v[2]*other[2])
+}
+
+// Multiply `other` with each `v` element.
+func (v Vector3i) Mul1(other int) Vector3i {
+	return Vec3i(v[0]*other, v[1]*other, v[2]*other)
+}
+
+// `v` and `other` pairwise divide.
+func (v Vector3i) Div(other Vector3i) Vector3i {
+	return Vec3i(v[0]/other[0], v[1]/other[1], v[2]/other[2])
+}
+
+// Divide `other` from each `v` element.
+func (v Vector3i) Div1(other int) Vector3i {
+	return Vec3i(v[0]/other, v[1]/other, v[2]/other)
+}
+
+// `v` and `other` pairwise wrap.
+func (v Vector3i) Wrap(lens Vector3i) Vector3i {
+	return Vec3i(Wrap(v[0], lens[0]), Wrap(v[1], lens[1]), Wrap(v[2], lens[2]))
+}
+
+// Wrap `len` to each `v` element.
+func (v Vector3i) Wrap1(len int) Vector3i {
+	return Vec3i(Wrap(v[0], len), Wrap(v[1], len), Wrap(v[2], len))
+}
+
+// Make `v` elements absolute.
+func (v Vector3i) Abs() Vector3i {
+	return Vec3i(Abs(v[0]), Abs(v[1]), Abs(v[2]))
+}
+
+// Lowest `v` element.
+func (v Vector3i) Min() int {
+	return Min(v[0], v[1], v[2])
+}
+
+// Highest `v` element.
+func (v Vector3i) Max() int {
+	return Max(v[0], v[1], v[2])
+}
+
+// `v` element sum.
+func (v Vector3i) Sum() int {
+	return v[0] + v[1] + v[2]
+}
+
+// Sign `v` elements.
+func (v Vector3i) Sign() Vector3i {
+	return Vec3i(Ternary(v[0] < 0, -1, 1), Ternary(v[1] < 0, -1, 1), Ternary(v[2] < 0, -1, 1))
+}
+
+// `v` and `other` dot product.
+func (v Vector3i) Dot(other Vector3i) int {
+	return v.Mul(other).Sum()
+}
+
+// Magnitude squared.
+func (v Vector3i) MagSq() int {
+	return v.Dot(v)
+}
+
+// Magnitude.
+func (v Vector3i) Mag() f64 {
+	return Sqrt(f64(v.MagSq()))
+}
+
+// Distance between `v` and `other`.
+func (v Vector3i) Dst(other Vector3i) f64 {
+	return v.Sub(other).Mag()
+}
+
+// Grid distance between `v` and `other`, moving along one axis at a time.
+func (v Vector3i) DstManhattan(other Vector3i) int {
+	return v.Sub(other).Abs().Sum()
+}
+
+// Grid distance between `v` and `other`, moving diagonally as well.
+func (v Vector3i) DstChebyshev(other Vector3i) int {
+	return v.Sub(other).Abs().Max()
+}
+
+// `v` and `other` cross product, perpendicular to both.
+func (v Vector3i) Cross(other Vector3i) Vector3i {
+	return Vec3i(
+		v[1]*other[2]-v[2]*other[1],
+		v[2]*other[0]-v[0]*other[2],
+		v[0]*other[1]-v[1]*other[0],
+	)
+}
+
+// `v` and `other` linear interpolation, rounded to the nearest grid point.
+func (v Vector3i) Lerp(other Vector3i, t f64) Vector3i {
+	return v.F64().Lerp(other.F64(), t).RoundI()
+}
+
+// Move `v` towards `other` by `dlt`, rounded to the nearest grid point.
+func (v Vector3i) MoveTowards(other Vector3i, dlt f64) Vector3i {
+	return v.F64().MoveTowards(other.F64(), dlt).RoundI()
+}
+
+// Convert elements to f32.
+func (v Vector3i) F32() Vector3F32 {
+	return Vec3F32(f32(v[0]), f32(v[1]), f32(v[2]))
+}
+
+// Convert elements to f64.
+func (v Vector3i) F64() Vector3 {
+	return Vec3(f64(v[0]), f64(v[1]), f64(v[2]))
+}
@@ -0,0 +1,186 @@
+package gomisc
+
+type Vector4 [4]f64
+
+// New Vector4.
+func Vec4(x, y, z, w f64) Vector4 {
+	return Vector4{x, y, z, w}
+}
+
+// Are `v` and `other` identical.
+func (v Vector4) Eq(other Vector4) bool {
+	return v[0] == other[0] && v[1] == other[1] && v[2] == other[2] && v[3] == other[3]
+}
+
+// Changes sign of each `v` element.
+func (v Vector4) Neg() Vector4 {
+	return Vec4(-v[0], -v[1], -v[2], -v[3])
+}
+
+// Reciprocates each `v` element.
+func (v Vector4) Rcp() Vector4 {
+	return Vec4(1/v[0], 1/v[1], 1/v[2], 1/v[3])
+}
+
+// `v` and `other` pairwise add.
+func (v Vector4) Add(other Vector4) Vector4 {
+	return Vec4(v[0]+other[0], v[1]+other[1], v[2]+other[2], v[3]+other[3])
+}
+
+// Add `other` to each `v` element.
+func (v Vector4) Add1(other f64) Vector4 {
+	return Vec4(v[0]+other, v[1]+other, v[2]+other, v[3]+other)
+}
+
+// `v` and `other` pairwise subtract.
+func (v Vector4) Sub(other Vector4) Vector4 {
+	return Vec4(v[0]-other[0], v[1]-other[1], v[2]-other[2], v[3]-other[3])
+}
+
+// Subtract `other` from each `v` element.
+func (v Vector4) Sub1(other f64) Vector4 {
+	return Vec4(v[0]-other, v[1]-other, v[2]-other, v[3]-other)
+}
+
+// `v` and `other` pairwise multiply.
+func (v Vector4) Mul(other Vector4) Vector4 {
+	return Vec4(v[0]*other[0], v[1]*other[1], v[2]*other[2], v[3]*other[3])
+}
+
+// Multiply `other` with each `v` element.
+func (v Vector4) Mul1(other f64) Vector4 {
+	return Vec4(v[0]*other, v[1]*other, v[2]*other, v[3]*other)
+}
+
+// `v` and `other` pairwise divide.
+func (v Vector4) Div(other Vector4) Vector4 {
+	return Vec4(v[0]/other[0], v[1]/other[1], v[2]/other[2], v[3]/other[3])
+}
+
+// Divide `other` from each `v` element.
+func (v Vector4) Div1(other f64) Vector4 {
+	return Vec4(v[0]/other, v[1]/other, v[2]/other, v[3]/other)
+}
+
+// `v` and `other` pairwise wrap.
+func (v Vector4) Wrap(lens Vector4) Vector4 {
+	return Vec4(Wrap(v[0], lens[0]), Wrap(v[1], lens[1]), Wrap(v[2], lens[2]), Wrap(v[3], lens[3]))
+}
+
+// Wrap `len` to each `v` element.
+func (v Vector4) Wrap1(len f64) Vector4 {
+	return Vec4(Wrap(v[0], len), Wrap(v[1], len), Wrap(v[2], len), Wrap(v[3], len))
+}
+
+// Make `v` elements absolute.
+func (v Vector4) Abs() Vector4 {
+	return Vec4(Abs(v[0]), Abs(v[1]), Abs(v[2]), Abs(v[3]))
+}
+
+// Lowest `v` element.
+func (v Vector4) Min() f64 {
+	return Min(v[0], v[1], v[2], v[3])
+}
+
+// Highest `v` element.
+func (v Vector4) Max() f64 {
+	return Max(v[0], v[1], v[2], v[3])
+}
+
+// `v` element sum.
+func (v Vector4) Sum() f64 {
+	return v[0] + v[1] + v[2] + v[3]
+}
+
+// Sign `v` elements.
+func (v Vector4) Sign() Vector4 {
+	return Vec4(Sign(v[0]), Sign(v[1]), Sign(v[2]), Sign(v[3]))
+}
+
+// Floor `v` elements.
+func (v Vector4) Floor() Vector4 {
+	return Vec4(Floor(v[0]), Floor(v[1]), Floor(v[2]), Floor(v[3]))
+}
+
+// Round `v` elements.
+func (v Vector4) Round() Vector4 {
+	return Vec4(Round(v[0]), Round(v[1]), Round(v[2]), Round(v[3]))
+}
+
+// `v` and `other` linear interpolation.
+func (v Vector4) Lerp(other Vector4, t f64) Vector4 {
+	return other.Sub(v).Mul1(t).Add(v)
+}
+
+// `v` and `other` dot product.
+func (v Vector4) Dot(other Vector4) f64 {
+	return v.Mul(other).Sum()
+}
+
+// Magnitude squared.
+func (v Vector4) MagSq() f64 {
+	return v.Dot(v)
+}
+
+// Magnitude.
+func (v Vector4) Mag() f64 {
+	return Sqrt(v.MagSq())
+}
+
+// `v` direction with `value` magnitude.
+func (v Vector4) MagSet(value f64) Vector4 {
+	if mag := v.Mag(); mag != 0 {
+		return v.Mul1(value / mag)
+	}
+	return Vector4{}
+}
+
+// `v` direction with 1 magnitude.
+func (v Vector4) Norm() Vector4 {
+	return v.MagSet(1)
+}
+
+// Clamps `v` magnitude.
+func (v Vector4) ClampMag(max f64) Vector4 {
+	if v.Mag() > max {
+		return v.MagSet(max)
+	}
+	return v
+}
+
+// Distance between `v` and `other`.
+func (v Vector4) Dst(other Vector4) f64 {
+	return v.Sub(other).Mag()
+}
+
+// Move `v` towards `other` by `dlt`.
+func (v Vector4) MoveTowards(other Vector4, dlt f64) Vector4 {
+	return other.Sub(v).MagSet(Min(dlt, v.Dst(other))).Add(v)
+}
+
+// Project `other` onto `v`, changing magnitude of `v`.
+// Both magnitudes affects result magnitude.
+func (v Vector4) Project(other Vector4) Vector4 {
+	return v.MagSet(v.Dot(other))
+}
+
+// Reflect `v` on normal `norm`.
+// `norm` should be normalized.
+func (v Vector4) Reflect(norm Vector4) Vector4 {
+	return v.Sub(norm.Mul1(v.Dot(norm) * 2))
+}
+
+// Convert elements to f32.
+func (v Vector4) F32() Vector4F32 {
+	return Vec4F32(f32(v[0]), f32(v[1]), f32(v[2]), f32(v[3]))
+}
+
+// Floor `v` elements into ints.
+func (v Vector4) FloorI() Vector4i {
+	return Vec4i(FloorI(v[0]), FloorI(v[1]), FloorI(v[2]), FloorI(v[3]))
+}
+
+// Round `v` elements into ints.
+func (v Vector4) RoundI() Vector4i {
+	return Vec4i(RoundI(v[0]), RoundI(v[1]), RoundI(v[2]), RoundI(v[3]))
+}
@@ -0,0 +1,186 @@
+package gomisc
+
+type Vector4F32 [4]f32
+
+// New Vector4F32.
+func Vec4F32(x, y, z, w f32) Vector4F32 {
+	return Vector4F32{x, y, z, w}
+}
+
+// Are `v` and `other` identical.
+func (v Vector4F32) Eq(other Vector4F32) bool {
+	return v[0] == other[0] && v[1] == other[1] && v[2] == other[2] && v[3] == other[3]
+}
+
+// Changes sign of each `v` element.
+func (v Vector4F32) Neg() Vector4F32 {
+	return Vec4F32(-v[0], -v[1], -v[2], -v[3])
+}
+
+// Reciprocates each `v` element.
+func (v Vector4F32) Rcp() Vector4F32 {
+	return Vec4F32(1/v[0], 1/v[1], 1/v[2], 1/v[3])
+}
+
+// `v` and `other` pairwise add.
+func (v Vector4F32) Add(other Vector4F32) Vector4F32 {
+	return Vec4F32(v[0]+other[0], v[1]+other[1], v[2]+other[2], v[3]+other[3])
+}
+
+// Add `other` to each `v` element.
+func (v Vector4F32) Add1(other f32) Vector4F32 {
+	return Vec4F32(v[0]+other, v[1]+other, v[2]+other, v[3]+other)
+}
+
+// `v` and `other` pairwise subtract.
+func (v Vector4F32) Sub(other Vector4F32) Vector4F32 {
+	return Vec4F32(v[0]-other[0], v[1]-other[1], v[2]-other[2], v[3]-other[3])
+}
+
+// Subtract `other` from each `v` element.
+func (v Vector4F32) Sub1(other f32) Vector4F32 {
+	return Vec4F32(v[0]-other, v[1]-other, v[2]-other, v[3]-other)
+}
+
+// `v` and `other` pairwise multiply.
+func (v Vector4F32) Mul(other Vector4F32) Vector4F32 {
+	return Vec4F32(v[0]*other[0], v[1]*other[1], v[2]*other[2], v[3]*other[3])
+}
+
+// Multiply `other` with each `v` element.
+func (v Vector4F32) Mul1(other f32) Vector4F32 {
+	return Vec4F32(v[0]*other, v[1]*other, v[2]*other, v[3]*other)
+}
+
+// `v` and `other` pairwise divide.
+func (v Vector4F32) Div(other Vector4F32) Vector4F32 {
+	return Vec4F32(v[0]/other[0], v[1]/other[1], v[2]/other[2], v[3]/other[3])
+}
+
+// Divide `other` from each `v` element.
+func (v Vector4F32) Div1(other f32) Vector4F32 {
+	return Vec4F32(v[0]/other, v[1]/other, v[2]/other, v[3]/other)
+}
+
+// `v` and `other` pairwise wrap.
+func (v Vector4F32) Wrap(lens Vector4F32) Vector4F32 {
+	return Vec4F32(Wrap(v[0], lens[0]), Wrap(v[1], lens[1]), Wrap(v[2], lens[2]), Wrap(v[3], lens[3]))
+}
+
+// Wrap `len` to each `v` element.
+func (v Vector4F32) Wrap1(len f32) Vector4F32 {
+	return Vec4F32(Wrap(v[0], len), Wrap(v[1], len), Wrap(v[2], len), Wrap(v[3], len))
+}
+
+// Make `v` elements absolute.
+func (v Vector4F32) Abs() Vector4F32 {
+	return Vec4F32(Abs(v[0]), Abs(v[1]), Abs(v[2]), Abs(v[3]))
+}
+
+// Lowest `v` element.
+func (v Vector4F32) Min() f32 {
+	return Min(v[0], v[1], v[2], v[3])
+}
+
+// Highest `v` element.
+func (v Vector4F32) Max() f32 {
+	return Max(v[0], v[1], v[2], v[3])
+}
+
+// `v` element sum.
+func (v Vector4F32) Sum() f32 {
+	return v[0] + v[1] + v[2] + v[3]
+}
+
+// Sign `v` elements.
+func (v Vector4F32) Sign() Vector4F32 {
+	return Vec4F32(Sign(v[0]), Sign(v[1]), Sign(v[2]), Sign(v[3]))
+}
+
+// Floor `v` elements.
+func (v Vector4F32) Floor() Vector4F32 {
+	return Vec4F32(f32(Floor(f64(v[0]))), f32(Floor(f64(v[1]))), f32(Floor(f64(v[2]))), f32(Floor(f64(v[3]))))
+}
+
+// Round `v` elements.
+func (v Vector4F32) Round() Vector4F32 {
+	return Vec4F32(f32(Round(f64(v[0]))), f32(Round(f64(v[1]))), f32(Round(f64(v[2]))), f32(Round(f64(v[3]))))
+}
+
+// `v` and `other` linear interpolation.
+func (v Vector4F32) Lerp(other Vector4F32, t f32) Vector4F32 {
+	return other.Sub(v).Mul1(t).Add(v)
+}
+
+// `v` and `other` dot product.
+func (v Vector4F32) Dot(other Vector4F32) f32 {
+	return v.Mul(other).Sum()
+}
+
+// Magnitude squared.
+func (v Vector4F32) MagSq() f32 {
+	return v.Dot(v)
+}
+
+// Magnitude.
+func (v Vector4F32) Mag() f32 {
+	return f32(Sqrt(f64(v.MagSq())))
+}
+
+// `v` direction with `value` magnitude.
+func (v Vector4F32) MagSet(value f32) Vector4F32 {
+	if mag := v.Mag(); mag != 0 {
+		return v.Mul1(value / mag)
+	}
+	return Vector4F32{}
+}
+
+// `v` direction with 1 magnitude.
+func (v Vector4F32) Norm() Vector4F32 {
+	return v.MagSet(1)
+}
+
+// Clamps `v` magnitude.
+func (v Vector4F32) ClampMag(max f32) Vector4F32 {
+	if v.Mag() > max {
+		return v.MagSet(max)
+	}
+	return v
+}
+
+// Distance between `v` and `other`.
+func (v Vector4F32) Dst(other Vector4F32) f32 {
+	return v.Sub(other).Mag()
+}
+
+// Move `v` towards `other` by `dlt`.
+func (v Vector4F32) MoveTowards(other Vector4F32, dlt f32) Vector4F32 {
+	return other.Sub(v).MagSet(Min(dlt, v.Dst(other))).Add(v)
+}
+
+// Project `other` onto `v`, changing magnitude of `v`.
+// Both magnitudes affects result magnitude.
+func (v Vector4F32) Project(other Vector4F32) Vector4F32 {
+	return v.MagSet(v.Dot(other))
+}
+
+// Reflect `v` on normal `norm`.
+// `norm` should be normalized.
+func (v Vector4F32) Reflect(norm Vector4F32) Vector4F32 {
+	return v.Sub(norm.Mul1(v.Dot(norm) * 2))
+}
+
+// Convert elements to f64.
+func (v Vector4F32) F64() Vector4 {
+	return Vec4(f64(v[0]), f64(v[1]), f64(v[2]), f64(v[3]))
+}
+
+// Floor `v` elements into ints.
+func (v Vector4F32) FloorI() Vector4i {
+	return Vec4i(FloorI(f64(v[0])), FloorI(f64(v[1])), FloorI(f64(v[2])), FloorI(f64(v[3])))
+}
+
+// Round `v` elements into ints.
+func (v Vector4F32) RoundI() Vector4i {
+	return Vec4i(RoundI(f64(v[0])), RoundI(f64(v[1])), RoundI(f64(v[2])), RoundI(f64(v[3])))
+}
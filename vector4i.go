@@ -0,0 +1,144 @@
+package gomisc
+
+type Vector4i [4]int
+
+// New Vector4i.
+func Vec4i(x, y, z, w int) Vector4i {
+	return Vector4i{x, y, z, w}
+}
+
+// Are `v` and `other` identical.
+func (v Vector4i) Eq(other Vector4i) bool {
+	return v[0] == other[0] && v[1] == other[1] && v[2] == other[2] && v[3] == other[3]
+}
+
+// Changes sign of each `v` element.
+func (v Vector4i) Neg() Vector4i {
+	return Vec4i(-v[0], -v[1], -v[2], -v[3])
+}
+
+// `v` and `other` pairwise add.
+func (v Vector4i) Add(other Vector4i) Vector4i {
+	return Vec4i(v[0]+other[0], v[1]+other[1], v[2]+other[2], v[3]+other[3])
+}
+
+// Add `other` to each `v` element.
+func (v Vector4i) Add1(other int) Vector4i {
+	return Vec4i(v[0]+other, v[1]+other, v[2]+other, v[3]+other)
+}
+
+// `v` and `other` pairwise subtract.
+func (v Vector4i) Sub(other Vector4i) Vector4i {
+	return Vec4i(v[0]-other[0], v[1]-other[1], v[2]-other[2], v[3]-other[3])
+}
+
+// Subtract `other` from each `v` element.
+func (v Vector4i) Sub1(other int) Vector4i {
+	return Vec4i(v[0]-other, v[1]-other, v[2]-other, v[3]-other)
+}
+
+// `v` and `other` pairwise multiply.
+func (v Vector4i) Mul(other Vector4i) Vector4i {
+	return Vec4i(v[0]*other[0], v[1]*other[1], v[2]*other[2], v[3]*other[3])
+}
+
+// Multiply `other` with each `v` element.
+func (v Vector4i) Mul1(other int) Vector4i {
+	return Vec4i(v[0]*other, v[1]*other, v[2]*other, v[3]*other)
+}
+
+// `v` and `other` pairwise divide.
+func (v Vector4i) Div(other Vector4i) Vector4i {
+	return Vec4i(v[0]/other[0], v[1]/other[1], v[2]/other[2], v[3]/other[3])
+}
+
+// Divide `other` from each `v` element.
+func (v Vector4i) Div1(other int) Vector4i {
+	return Vec4i(v[0]/other, v[1]/other, v[2]/other, v[3]/other)
+}
+
+// `v` and `other` pairwise wrap.
+func (v Vector4i) Wrap(lens Vector4i) Vector4i {
+	return Vec4i(Wrap(v[0], lens[0]), Wrap(v[1], lens[1]), Wrap(v[2], lens[2]), Wrap(v[3], lens[3]))
+}
+
+// Wrap `len` to each `v` element.
+func (v Vector4i) Wrap1(len int) Vector4i {
+	return Vec4i(Wrap(v[0], len), Wrap(v[1], len), Wrap(v[2], len), Wrap(v[3], len))
+}
+
+// Make `v` elements absolute.
+func (v Vector4i) Abs() Vector4i {
+	return Vec4i(Abs(v[0]), Abs(v[1]), Abs(v[2]), Abs(v[3]))
+}
+
+// Lowest `v` element.
+func (v Vector4i) Min() int {
+	return Min(v[0], v[1], v[2], v[3])
+}
+
+// Highest `v` element.
+func (v Vector4i) Max() int {
+	return Max(v[0], v[1], v[2], v[3])
+}
+
+// `v` element sum.
+func (v Vector4i) Sum() int {
+	return v[0] + v[1] + v[2] + v[3]
+}
+
+// Sign `v` elements.
+func (v Vector4i) Sign() Vector4i {
+	return Vec4i(Ternary(v[0] < 0, -1, 1), Ternary(v[1] < 0, -1, 1),
+		Ternary(v[2] < 0, -1, 1), Ternary(v[3] < 0, -1, 1))
+}
+
+// `v` and `other` dot product.
+func (v Vector4i) Dot(other Vector4i) int {
+	return v.Mul(other).Sum()
+}
+
+// Magnitude squared.
+func (v Vector4i) MagSq() int {
+	return v.Dot(v)
+}
+
+// Magnitude.
+func (v Vector4i) Mag() f64 {
+	return Sqrt(f64(v.MagSq()))
+}
+
+// Distance between `v` and `other`.
+func (v Vector4i) Dst(other Vector4i) f64 {
+	return v.Sub(other).Mag()
+}
+
+// Grid distance between `v` and `other`, moving along one axis at a time.
+func (v Vector4i) DstManhattan(other Vector4i) int {
+	return v.Sub(other).Abs().Sum()
+}
+
+// Grid distance between `v` and `other`, moving diagonally as well.
+func (v Vector4i) DstChebyshev(other Vector4i) int {
+	return v.Sub(other).Abs().Max()
+}
+
+// `v` and `other` linear interpolation, rounded to the nearest grid point.
+func (v Vector4i) Lerp(other Vector4i, t f64) Vector4i {
+	return v.F64().Lerp(other.F64(), t).RoundI()
+}
+
+// Move `v` towards `other` by `dlt`, rounded to the nearest grid point.
+func (v Vector4i) MoveTowards(other Vector4i, dlt f64) Vector4i {
+	return v.F64().MoveTowards(other.F64(), dlt).RoundI()
+}
+
+// Convert elements to f32.
+func (v Vector4i) F32() Vector4F32 {
+	return Vec4F32(f32(v[0]), f32(v[1]), f32(v[2]), f32(v[3]))
+}
+
+// Convert elements to f64.
+func (v Vector4i) F64() Vector4 {
+	return Vec4(f64(v[0]), f64(v[1]), f64(v[2]), f64(v[3]))
+}
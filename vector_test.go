@@ -0,0 +1,98 @@
+package gomisc
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Method names of `v`, without the element conversions that differ by type
+// and the encoding only Vector2 has.
+func vectorMethods(v any) map[string]bool {
+	result := map[string]bool{}
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumMethod(); i++ {
+		result[t.Method(i).Name] = true
+	}
+	for _, name := range []string{"F32", "F64", "String", "MarshalText", "MarshalJSON", "MarshalBinary"} {
+		delete(result, name)
+	}
+	return result
+}
+
+// Variants of a vector type share its method vocabulary.
+func TestVectorMethodSets(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		a, b any
+		// Only defined where the dimension allows it
+		skip []string
+	}{
+		{"Vector2F32", Vector2{}, Vector2F32{}, nil},
+		{"Vector3F32", Vector3{}, Vector3F32{}, nil},
+		{"Vector4F32", Vector4{}, Vector4F32{}, nil},
+		{"Vector2i", Vector3i{}, Vector2i{}, []string{"Cross", "Rot90", "ReverseOrder"}},
+		{"Vector4i", Vector3i{}, Vector4i{}, []string{"Cross"}},
+	} {
+		a, b := vectorMethods(c.a), vectorMethods(c.b)
+		for _, name := range c.skip {
+			delete(a, name)
+			delete(b, name)
+		}
+		for name := range a {
+			if !b[name] {
+				t.Errorf("%s lacks %s", c.name, name)
+			}
+		}
+		for name := range b {
+			if !a[name] {
+				t.Errorf("%s has extra %s", c.name, name)
+			}
+		}
+	}
+	// Integer vectors take every float method meaningful on a grid
+	floats, ints := vectorMethods(Vector2{}), vectorMethods(Vector2i{})
+	for _, name := range []string{"Lerp", "MoveTowards", "Dst", "Mag", "Dot", "Cross", "Rot90"} {
+		if !floats[name] || !ints[name] {
+			t.Errorf("Vector2 and Vector2i must both have %s", name)
+		}
+	}
+}
+
+func TestVectorGridMoves(t *testing.T) {
+	a, b := Vec2i(0, 0), Vec2i(10, 4)
+	if got := a.Lerp(b, .5); got != Vec2i(5, 2) {
+		t.Errorf("Lerp = %v", got)
+	}
+	if got := a.MoveTowards(Vec2i(10, 0), 3); got != Vec2i(3, 0) {
+		t.Errorf("MoveTowards = %v", got)
+	}
+	if got := a.MoveTowards(b, 100); got != b {
+		t.Errorf("MoveTowards past target = %v", got)
+	}
+	if got := Vec4(1.5, -1.5, 2.4, -.6).FloorI(); got != Vec4i(1, -2, 2, -1) {
+		t.Errorf("Vector4 FloorI = %v", got)
+	}
+	if got := Vec4F32(1.5, -1.4, 2.6, -.6).RoundI(); got != Vec4i(2, -1, 3, -1) {
+		t.Errorf("Vector4F32 RoundI = %v", got)
+	}
+}
+
+// Float32 variants agree with the float64 methods they mirror.
+func TestVectorF32(t *testing.T) {
+	v := Vec2(3, 4)
+	if got, want := v.F32().Rot(Pi/3).F64(), v.Rot(Pi/3); got.Dst(want) > 1e-5 {
+		t.Errorf("Vector2F32 Rot = %v, want %v", got, want)
+	}
+	if got, want := v.F32().AngTo(Vec2F32(-1, 2)), v.AngTo(Vec2(-1, 2)); Abs(f64(got-want)) > 1e-6 {
+		t.Errorf("Vector2F32 AngTo = %v, want %v", got, want)
+	}
+	w := Vec3(1, 2, 3)
+	axis := Vec3(0, 0, 1)
+	if got, want := w.F32().Rotate(axis.F32(), Pi/2).F64(), w.Rotate(axis, Pi/2); got.Dst(want) > 1e-5 {
+		t.Errorf("Vector3F32 Rotate = %v, want %v", got, want)
+	}
+	mag, polar, azimuth := w.F32().Spherical()
+	if got := Vec3F32Spherical(mag, polar, azimuth).F64(); got.Dst(w) > 1e-5 {
+		t.Errorf("Vector3F32 Spherical round trip = %v", got)
+	}
+}